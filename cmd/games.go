@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+	"javorszky/dice-territory-game/v2/pkg/render"
+)
+
+// games holds the games being played on this server by session.
+type games struct {
+	mu       sync.Mutex
+	sessions map[string]*game.Game
+}
+
+func newGames() *games {
+	return &games{sessions: make(map[string]*game.Game)}
+}
+
+// ServeHTTP handles the routes under /games/:
+//
+//	POST /games/{session}            creates a game from the width, height, map and player form values
+//	GET  /games/{session}/board.svg  draws the board as SVG
+//	GET  /games/{session}/board.png  draws the board as PNG
+func (gs *games) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/games/"), "/")
+	if parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		gs.create(w, r, parts[0])
+	case len(parts) == 2 && r.Method == http.MethodGet:
+		gs.board(w, r, parts[0], parts[1])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (gs *games) create(w http.ResponseWriter, r *http.Request, session string) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var config game.Config
+	if name := r.Form.Get("map"); name != "" {
+		m, ok := game.BuiltinMap(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown map %q, pick one of %v", name, game.BuiltinMaps()), http.StatusBadRequest)
+			return
+		}
+		config.Map = m
+	}
+
+	// The size of the board comes from the map, if there is one.
+	var width, height uint64
+	var err error
+	if config.Map == nil || r.Form.Get("width") != "" {
+		if width, err = strconv.ParseUint(r.Form.Get("width"), 10, 8); err != nil {
+			http.Error(w, fmt.Sprintf("bad width %q", r.Form.Get("width")), http.StatusBadRequest)
+			return
+		}
+	}
+	if config.Map == nil || r.Form.Get("height") != "" {
+		if height, err = strconv.ParseUint(r.Form.Get("height"), 10, 8); err != nil {
+			http.Error(w, fmt.Sprintf("bad height %q", r.Form.Get("height")), http.StatusBadRequest)
+			return
+		}
+	}
+
+	g, err := game.NewWithConfig(session, config, uint8(width), uint8(height), r.Form["player"]...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if _, ok := gs.sessions[session]; ok {
+		http.Error(w, fmt.Sprintf("session %q already exists", session), http.StatusConflict)
+		return
+	}
+	gs.sessions[session] = &g
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (gs *games) board(w http.ResponseWriter, r *http.Request, session string, file string) {
+	gs.mu.Lock()
+	g, ok := gs.sessions[session]
+	var snapshot game.Snapshot
+	if ok {
+		snapshot = g.Board.Snapshot()
+	}
+	gs.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	// The game plays on while the board renders, so it renders from the snapshot.
+	board := snapshot.Board()
+	opts := render.Options{Highlight: lastPlacement(board)}
+	var err error
+	switch file {
+	case "board.svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+		err = render.SVG(w, board, opts)
+	case "board.png":
+		w.Header().Set("Content-Type", "image/png")
+		err = render.PNG(w, board, opts)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		log.Printf("render %s of %q: %v", file, session, err)
+	}
+}
+
+// lastPlacement returns where the last piece on the board was placed, nil if there are none yet.
+func lastPlacement(b game.Board) *game.Placement {
+	if len(b.Pieces) == 0 {
+		return nil
+	}
+	p := b.Pieces[len(b.Pieces)-1]
+	return &game.Placement{Origin: p.Origin, Width: p.Width, Height: p.Height}
+}
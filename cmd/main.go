@@ -91,6 +91,7 @@ func main() {
 	flag.Parse()
 	log.SetFlags(0)
 	http.HandleFunc("/echo", echo)
+	http.Handle("/games/", newGames())
 	http.HandleFunc("/", home)
 	fmt.Printf("listening on %s\n", *addr)
 	log.Fatal(http.ListenAndServe(*addr, nil))
@@ -0,0 +1,19 @@
+package ai
+
+import (
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// Greedy places the piece where it scores the most right away, by the scoring of the board. With the standard scoring
+// every placement of a roll is worth the same, so ties go to the placement that leaves the player bordering the most
+// free cells to grow into.
+type Greedy struct{}
+
+// Choose picks the legal placement with the highest score after placing, then the largest frontier.
+func (Greedy) Choose(s game.Snapshot, playerID string, roll game.Roll) (game.Placement, bool) {
+	// The frontier can't be larger than the board, so it only ever breaks ties between scores.
+	cells := int(s.Width())*int(s.Height()) + 1
+	return best(s, playerID, roll, func(after *game.Board) int {
+		return score(after, playerID)*cells + int(after.Frontier(playerID))
+	})
+}
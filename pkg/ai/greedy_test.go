@@ -0,0 +1,61 @@
+package ai_test
+
+import (
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/ai"
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestGreedy_Choose(t *testing.T) {
+	tests := []struct {
+		name   string
+		scorer game.Scorer
+		pieces []game.Piece
+		roll   game.Roll
+		want   game.Placement
+		wantOK bool
+	}{
+		{
+			// Down from B2 borders 14 free cells, along from C1 only 13.
+			name:   "breaks ties by the room left to grow",
+			pieces: []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 2, 1)},
+			roll:   game.Roll{Width: 1, Height: 6},
+			want:   game.Placement{Origin: game.Coordinate{X: 2, Y: 2}, Width: 1, Height: 6},
+			wantOK: true,
+		},
+		{
+			name:   "completes a row for the bonus",
+			scorer: game.RowBonusScorer{Bonus: 10},
+			pieces: []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 2, 1)},
+			roll:   game.Roll{Width: 1, Height: 6},
+			want:   game.Placement{Origin: game.Coordinate{X: 3, Y: 1}, Width: 6, Height: 1, Rotated: true},
+			wantOK: true,
+		},
+		{
+			name:   "passes when the piece fits nowhere",
+			pieces: []game.Piece{mustNewPiece(t, "playerTwo", 1, 1, 1, 1)},
+			roll:   game.Roll{Width: 1, Height: 1},
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Scorer = tt.scorer
+			b.Pieces = tt.pieces
+			before := b.Clone()
+
+			got, ok := ai.Greedy{}.Choose(b.Snapshot(), "playerOne", tt.roll)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("Choose() = %v, %t, want %v, %t", got, ok, tt.want, tt.wantOK)
+			}
+			if len(b.Pieces) != len(before.Pieces) {
+				t.Errorf("Choose() changed the board")
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package ai
+
+import (
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// DefaultHeuristic weighs keeping room to grow about as much as taking it away from the others.
+var DefaultHeuristic = Heuristic{Score: 4, Frontier: 2, Blocking: 1}
+
+// Heuristic rates placements by the position they leave behind: the player's score, how many free cells they border
+// and so can grow into, and how many free cells their opponents border.
+type Heuristic struct {
+	// Score is the weight of the player's score.
+	Score int
+	// Frontier is the weight of the free cells along the player's pieces.
+	Frontier int
+	// Blocking is the weight of the free cells along the opponents' pieces, which count against the placement.
+	Blocking int
+}
+
+// Choose picks the legal placement that leaves the best rated position.
+func (h Heuristic) Choose(s game.Snapshot, playerID string, roll game.Roll) (game.Placement, bool) {
+	return best(s, playerID, roll, func(after *game.Board) int {
+		return h.Evaluate(after, playerID)
+	})
+}
+
+// Evaluate rates the position on the board for the player.
+func (h Heuristic) Evaluate(b *game.Board, playerID string) int {
+	v := h.Score*score(b, playerID) + h.Frontier*int(b.Frontier(playerID))
+	for _, p := range b.Players {
+		if p.ID != playerID {
+			v -= h.Blocking * int(b.Frontier(p.ID))
+		}
+	}
+	return v
+}
@@ -0,0 +1,92 @@
+package ai_test
+
+import (
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/ai"
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestHeuristic_Evaluate(t *testing.T) {
+	tests := []struct {
+		name      string
+		heuristic ai.Heuristic
+		want      int
+	}{
+		{
+			name:      "score only",
+			heuristic: ai.Heuristic{Score: 1},
+			want:      6,
+		},
+		{
+			name:      "frontier only",
+			heuristic: ai.Heuristic{Frontier: 1},
+			want:      5,
+		},
+		{
+			name:      "opponent frontier counts against",
+			heuristic: ai.Heuristic{Blocking: 1},
+			want:      -1,
+		},
+		{
+			name:      "default weights",
+			heuristic: ai.DefaultHeuristic,
+			want:      4*6 + 2*5 - 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			if _, err := b.PlacePiece(mustNewPiece(t, "playerOne", 1, 1, 2, 3)); err != nil {
+				t.Fatalf("PlacePiece() error = %v", err)
+			}
+
+			if got := tt.heuristic.Evaluate(&b, "playerOne"); got != tt.want {
+				t.Errorf("Evaluate() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHeuristic_Choose(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for _, p := range []game.Piece{
+		mustNewPiece(t, "playerOne", 1, 1, 3, 3),
+		mustNewPiece(t, "playerTwo", 4, 7, 5, 6),
+	} {
+		if _, err := b.PlacePiece(p); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+	roll := game.Roll{Width: 2, Height: 3}
+
+	got, ok := ai.DefaultHeuristic.Choose(b.Snapshot(), "playerOne", roll)
+	if !ok {
+		t.Fatalf("Choose() passed")
+	}
+
+	// Nothing legal is rated higher than the chosen placement.
+	rate := func(pl game.Placement) int {
+		after := b.Clone()
+		p, err := pl.Piece("playerOne")
+		if err != nil {
+			t.Fatalf("Piece() error = %v", err)
+		}
+		if _, err := after.PlacePiece(p); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+		return ai.DefaultHeuristic.Evaluate(&after, "playerOne")
+	}
+	top := rate(got)
+	for _, pl := range b.LegalPlacements("playerOne", roll.Width, roll.Height) {
+		if v := rate(pl); v > top {
+			t.Errorf("Choose() = %v rated %d, but %v is rated %d", got, top, pl, v)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package ai
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+const (
+	// DefaultIterations is how many simulations MCTS runs if it has neither a time budget nor an iteration limit.
+	DefaultIterations = 1000
+	// DefaultRolloutTurns is how many turns a simulation plays at random before the position is scored.
+	DefaultRolloutTurns = 8
+	// DefaultExploration is the UCT exploration constant.
+	DefaultExploration = math.Sqrt2
+)
+
+// MCTSConfig sets how hard MCTS thinks.
+type MCTSConfig struct {
+	// Budget is how long a single choice may take. Zero means no time limit.
+	Budget time.Duration
+	// Iterations is the most simulations a single choice runs. Zero means no limit, unless Budget is zero too, in
+	// which case DefaultIterations is used.
+	Iterations int
+	// RolloutTurns is how many turns a simulation plays past the tree before scoring. Zero means DefaultRolloutTurns.
+	RolloutTurns int
+	// Exploration is the UCT exploration constant. Zero means DefaultExploration.
+	Exploration float64
+	// Seed seeds the simulated dice and random choices, so the same seed makes the same choices for the same
+	// iteration limit.
+	Seed int64
+}
+
+// MCTS searches for the best placement with Monte Carlo tree search. Rolls are chance nodes: after every placement in
+// the tree the next roll is sampled from the dice, and each distinct roll gets its own subtree. Simulations play random
+// legal placements for a few turns and score the position by the players' share of the total score.
+//
+// MCTS keeps its random state between choices, so it must not be used from several goroutines at once.
+type MCTS struct {
+	config MCTSConfig
+	rng    *rand.Rand
+	// dice roll the simulated turns with the faces of the board being searched.
+	dice game.Dice
+	now  func() time.Time
+}
+
+// NewMCTS returns an MCTS strategy with the config.
+func NewMCTS(config MCTSConfig) *MCTS {
+	return &MCTS{config: config, rng: rand.New(rand.NewSource(config.Seed)), now: time.Now}
+}
+
+// decision is a node of the tree where a player chooses what to do with their roll.
+type decision struct {
+	visits  int
+	edges   []*edge
+	untried []edge
+	// expanded is set once the moves available here are known.
+	expanded bool
+}
+
+// edge is a move from a decision node. It also works as the chance node after the move, with a decision node for
+// every roll of the next player seen so far.
+type edge struct {
+	player    int
+	placement game.Placement
+	pass      bool
+	visits    int
+	reward    float64
+	outcomes  map[game.Roll]*decision
+}
+
+// position is the state of a simulated game.
+type position struct {
+	board   game.Board
+	current int
+	passes  int
+	// passLimit is how many passes in a row end the game.
+	passLimit int
+}
+
+// Choose runs simulations from the position until the budget or the iteration limit runs out, then picks the move
+// that was simulated most often. It only passes if the piece can't be placed. The simulated turns roll the dice of the
+// snapshot and end the game at its pass limit.
+func (m *MCTS) Choose(s game.Snapshot, playerID string, roll game.Roll) (game.Placement, bool) {
+	legal := s.LegalPlacements(playerID, roll.Width, roll.Height)
+	switch len(legal) {
+	case 0:
+		return game.Placement{}, false
+	case 1:
+		return legal[0], true
+	}
+
+	dice, err := game.NewDiceWithFaces(m.rng, s.Dice())
+	if err != nil {
+		return legal[0], true
+	}
+	m.dice = dice
+
+	start := position{board: s.Board(), current: playerIndex(s.Players(), playerID), passLimit: s.PassLimit()}
+	start.board.Roll = nil
+
+	chosen := m.search(start, roll).mostVisited()
+	if chosen == nil || chosen.pass {
+		return legal[0], true
+	}
+	return chosen.placement, true
+}
+
+// playerIndex returns the index of the player, 0 if they aren't playing.
+func playerIndex(players []game.Player, playerID string) int {
+	for i, p := range players {
+		if p.ID == playerID {
+			return i
+		}
+	}
+	return 0
+}
+
+// search runs simulations from the position with the roll until the budget or the iteration limit runs out, and
+// returns the root of the tree.
+func (m *MCTS) search(start position, roll game.Roll) *decision {
+	iterations := m.config.Iterations
+	if iterations == 0 && m.config.Budget == 0 {
+		iterations = DefaultIterations
+	}
+	deadline := m.now().Add(m.config.Budget)
+
+	root := &decision{}
+	for i := 0; iterations == 0 || i < iterations; i++ {
+		if m.config.Budget > 0 && i > 0 && !m.now().Before(deadline) {
+			break
+		}
+
+		pos := start
+		pos.board = start.board.Clone()
+		m.iterate(root, pos, roll)
+	}
+	return root
+}
+
+// mostVisited returns the move that was simulated most often, the first one on ties, or nil if none was.
+func (n *decision) mostVisited() *edge {
+	var chosen *edge
+	for _, e := range n.edges {
+		if chosen == nil || e.visits > chosen.visits {
+			chosen = e
+		}
+	}
+	return chosen
+}
+
+// iterate runs a single simulation: walks down the tree, adds a node, plays on at random and backs the rewards up.
+func (m *MCTS) iterate(root *decision, pos position, roll game.Roll) {
+	n := root
+	nodes := []*decision{root}
+	var path []*edge
+
+	for {
+		if !n.expanded {
+			n.expand(&pos, roll)
+		}
+
+		var e *edge
+		if len(n.untried) > 0 {
+			i := m.rng.Intn(len(n.untried))
+			tried := n.untried[i]
+			n.untried[i] = n.untried[len(n.untried)-1]
+			n.untried = n.untried[:len(n.untried)-1]
+			e = &tried
+			n.edges = append(n.edges, e)
+		} else {
+			e = n.selectEdge(m.exploration())
+		}
+
+		pos.play(e)
+		path = append(path, e)
+
+		if pos.over() || e.visits == 0 {
+			break
+		}
+
+		roll = m.dice.Roll()
+		next, ok := e.outcomes[roll]
+		if !ok {
+			if e.outcomes == nil {
+				e.outcomes = make(map[game.Roll]*decision)
+			}
+			next = &decision{}
+			e.outcomes[roll] = next
+			n = next
+			nodes = append(nodes, n)
+			break
+		}
+		n = next
+		nodes = append(nodes, n)
+	}
+
+	m.rollout(&pos)
+	rewards := pos.rewards()
+
+	for _, n := range nodes {
+		n.visits++
+	}
+	for _, e := range path {
+		e.visits++
+		e.reward += rewards[e.player]
+	}
+}
+
+// expand lists the moves of the current player with the roll: every legal placement, or a pass if there is none.
+func (n *decision) expand(pos *position, roll game.Roll) {
+	n.expanded = true
+
+	player := pos.current
+	for _, pl := range pos.board.LegalPlacements(pos.board.Players[player].ID, roll.Width, roll.Height) {
+		n.untried = append(n.untried, edge{player: player, placement: pl})
+	}
+	if len(n.untried) == 0 {
+		n.untried = append(n.untried, edge{player: player, pass: true})
+	}
+}
+
+// selectEdge picks the move with the best upper confidence bound for the player making it.
+func (n *decision) selectEdge(exploration float64) *edge {
+	var chosen *edge
+	top := math.Inf(-1)
+	log := math.Log(float64(n.visits))
+
+	for _, e := range n.edges {
+		v := e.reward/float64(e.visits) + exploration*math.Sqrt(log/float64(e.visits))
+		if v > top {
+			chosen, top = e, v
+		}
+	}
+	return chosen
+}
+
+// rollout plays random legal placements for a few turns, or until the game is over.
+func (m *MCTS) rollout(pos *position) {
+	turns := m.config.RolloutTurns
+	if turns == 0 {
+		turns = DefaultRolloutTurns
+	}
+
+	for i := 0; i < turns && !pos.over(); i++ {
+		roll := m.dice.Roll()
+		player := pos.current
+		legal := pos.board.LegalPlacements(pos.board.Players[player].ID, roll.Width, roll.Height)
+		if len(legal) == 0 {
+			pos.play(&edge{player: player, pass: true})
+			continue
+		}
+		pos.play(&edge{player: player, placement: legal[m.rng.Intn(len(legal))]})
+	}
+}
+
+func (m *MCTS) exploration() float64 {
+	if m.config.Exploration != 0 {
+		return m.config.Exploration
+	}
+	return DefaultExploration
+}
+
+// play makes the move and hands the turn to the next player, unless a placed double earns the player another turn. The
+// moves come from LegalPlacements, but a ruleset could still turn one down: the placement is skipped and the player
+// passes instead.
+func (pos *position) play(e *edge) {
+	if e.pass || !pos.place(e) {
+		pos.passes++
+	} else {
+		pos.passes = 0
+		if e.placement.Width == e.placement.Height && pos.board.Doubles == game.DoublesExtraTurn {
+			return
+		}
+	}
+	pos.current = (pos.current + 1) % len(pos.board.Players)
+}
+
+// place puts the piece of the move on the board, and returns false if the board turns it down.
+func (pos *position) place(e *edge) bool {
+	p, err := e.placement.Piece(pos.board.Players[e.player].ID)
+	if err != nil {
+		return false
+	}
+	_, err = pos.board.PlacePiece(p)
+	return err == nil
+}
+
+// over checks whether the simulated game has ended.
+func (pos *position) over() bool {
+	return pos.passes >= pos.passLimit || pos.board.FreeCells() == 0
+}
+
+// rewards returns every player's share of the total score, or an equal share if nobody has scored.
+func (pos *position) rewards() []float64 {
+	rewards := make([]float64, len(pos.board.Players))
+
+	var total uint
+	for _, p := range pos.board.Players {
+		total += p.Score
+	}
+	for i, p := range pos.board.Players {
+		if total == 0 {
+			rewards[i] = 1 / float64(len(rewards))
+			continue
+		}
+		rewards[i] = float64(p.Score) / float64(total)
+	}
+	return rewards
+}
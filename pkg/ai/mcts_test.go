@@ -0,0 +1,163 @@
+package ai_test
+
+import (
+	"testing"
+	"time"
+
+	"javorszky/dice-territory-game/v2/pkg/ai"
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestMCTS_Choose(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for _, p := range []game.Piece{
+		mustNewPiece(t, "playerOne", 1, 1, 3, 3),
+		mustNewPiece(t, "playerTwo", 4, 7, 5, 6),
+	} {
+		if _, err := b.PlacePiece(p); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+	roll := game.Roll{Width: 2, Height: 3}
+	legal := b.LegalPlacements("playerOne", roll.Width, roll.Height)
+	before := b.Clone()
+
+	config := ai.MCTSConfig{Iterations: 200, Seed: 5}
+	got, ok := ai.NewMCTS(config).Choose(b.Snapshot(), "playerOne", roll)
+	if !ok {
+		t.Fatalf("Choose() passed with %d legal placements", len(legal))
+	}
+	if !contains(legal, got) {
+		t.Errorf("Choose() = %v, not a legal placement", got)
+	}
+	if len(b.Pieces) != len(before.Pieces) || b.Players[0].Score != before.Players[0].Score {
+		t.Errorf("Choose() changed the board")
+	}
+
+	again, _ := ai.NewMCTS(config).Choose(b.Snapshot(), "playerOne", roll)
+	if again != got {
+		t.Errorf("Choose() = %v and %v with the same seed", got, again)
+	}
+}
+
+func TestMCTS_Choose_Pass(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	b.Pieces = []game.Piece{mustNewPiece(t, "playerTwo", 1, 1, 1, 1)}
+
+	if got, ok := ai.NewMCTS(ai.MCTSConfig{Seed: 1}).Choose(b.Snapshot(), "playerOne", game.Roll{Width: 1, Height: 1}); ok {
+		t.Errorf("Choose() = %v, want a pass", got)
+	}
+}
+
+// fickle is a ruleset that turns down every other placement it's asked about, including ones it allowed before.
+type fickle struct {
+	calls *int
+}
+
+func (r fickle) CanPlace(*game.Board, game.Piece, bool) bool {
+	*r.calls++
+	return *r.calls%2 == 0
+}
+
+func TestMCTS_Choose_TurnedDown(t *testing.T) {
+	g, err := game.NewWithConfig("ai", game.Config{Seed: 1, Ruleset: fickle{calls: new(int)}, PassLimit: 6}, 8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	roll := game.Roll{Width: 2, Height: 3}
+
+	// The simulated placements the ruleset turns down are skipped instead of stopping the search.
+	if _, ok := ai.NewMCTS(ai.MCTSConfig{Iterations: 100, Seed: 1}).Choose(g.Snapshot(), "playerOne", roll); !ok {
+		t.Errorf("Choose() passed")
+	}
+}
+
+func TestMCTS_Choose_Budget(t *testing.T) {
+	g := newGame(t, 42)
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+
+	budget := 50 * time.Millisecond
+	start := time.Now()
+	if _, ok := ai.NewMCTS(ai.MCTSConfig{Budget: budget, Seed: 1}).Choose(g.Board.Snapshot(), "playerTwo", *g.Board.Roll); !ok {
+		t.Fatalf("Choose() passed")
+	}
+	if elapsed := time.Since(start); elapsed > 10*budget {
+		t.Errorf("Choose() took %s with a budget of %s", elapsed, budget)
+	}
+}
+
+// selfPlay plays games of MCTS against greedy, switching who goes first every game, and returns how many MCTS won,
+// drew and lost.
+func selfPlay(tb testing.TB, games int, config ai.MCTSConfig) (won int, drew int, lost int) {
+	tb.Helper()
+
+	for i := 0; i < games; i++ {
+		g := newGame(tb, int64(i+1))
+		config.Seed = int64(i + 1)
+		strategies := []ai.Strategy{ai.NewMCTS(config), ai.Greedy{}}
+		mcts := "playerOne"
+		if i%2 == 1 {
+			strategies[0], strategies[1] = strategies[1], strategies[0]
+			mcts = "playerTwo"
+		}
+
+		for g.Phase != game.GameOver {
+			if err := ai.Turn(&g, strategies[g.Current]); err != nil {
+				tb.Fatalf("Turn() error = %v", err)
+			}
+		}
+
+		r, err := g.Result()
+		if err != nil {
+			tb.Fatalf("Result() error = %v", err)
+		}
+		switch {
+		case r.Draw:
+			drew++
+		case r.Winner == mcts:
+			won++
+		default:
+			lost++
+		}
+	}
+
+	return won, drew, lost
+}
+
+func TestMCTS_BeatsGreedy(t *testing.T) {
+	if testing.Short() {
+		t.Skip("self-play is slow")
+	}
+
+	won, drew, lost := selfPlay(t, 30, ai.MCTSConfig{Iterations: 300})
+	t.Logf("MCTS won %d, drew %d and lost %d games against greedy", won, drew, lost)
+	if won <= lost {
+		t.Errorf("MCTS won %d, drew %d and lost %d games against greedy", won, drew, lost)
+	}
+}
+
+func BenchmarkMCTS_SelfPlay(b *testing.B) {
+	var won, lost, games int
+	for i := 0; i < b.N; i++ {
+		w, _, l := selfPlay(b, 10, ai.MCTSConfig{Iterations: 300})
+		won += w
+		lost += l
+		games += 10
+	}
+	b.ReportMetric(float64(won)/float64(games), "wins/game")
+	b.ReportMetric(float64(lost)/float64(games), "losses/game")
+}
@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"errors"
+	"math/rand"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// Random places the piece at any of its legal placements with equal chance. It only passes if it has to.
+type Random struct {
+	source game.Source
+}
+
+// NewRandom returns a random strategy drawing from the source.
+func NewRandom(source game.Source) (Random, error) {
+	if source == nil {
+		return Random{}, errors.New("ai.NewRandom(): source is nil")
+	}
+	return Random{source: source}, nil
+}
+
+// NewSeededRandom returns a random strategy that makes the same choices every time for the same seed.
+func NewSeededRandom(seed int64) Random {
+	return Random{source: rand.New(rand.NewSource(seed))}
+}
+
+// Choose picks one of the legal placements at random.
+func (r Random) Choose(s game.Snapshot, playerID string, roll game.Roll) (game.Placement, bool) {
+	legal := s.LegalPlacements(playerID, roll.Width, roll.Height)
+	if len(legal) == 0 {
+		return game.Placement{}, false
+	}
+	return legal[r.source.Intn(len(legal))], true
+}
@@ -0,0 +1,68 @@
+package ai_test
+
+import (
+	"reflect"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/ai"
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestNewRandom(t *testing.T) {
+	if _, err := ai.NewRandom(nil); err == nil {
+		t.Errorf("NewRandom() error = nil, want an error for a nil source")
+	}
+}
+
+func TestRandom_Choose(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	b.Pieces = []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 2, 2)}
+	roll := game.Roll{Width: 2, Height: 1}
+	legal := b.LegalPlacements("playerOne", roll.Width, roll.Height)
+
+	seen := make(map[game.Placement]bool)
+	for i := int64(0); i < 50; i++ {
+		got, ok := ai.NewSeededRandom(i).Choose(b.Snapshot(), "playerOne", roll)
+		if !ok {
+			t.Fatalf("Choose() passed with %d legal placements", len(legal))
+		}
+		if !contains(legal, got) {
+			t.Fatalf("Choose() = %v, not a legal placement", got)
+		}
+		seen[got] = true
+	}
+	if len(seen) < 2 {
+		t.Errorf("Choose() picked %d different placements out of %d over 50 seeds", len(seen), len(legal))
+	}
+
+	first, _ := ai.NewSeededRandom(7).Choose(b.Snapshot(), "playerOne", roll)
+	second, _ := ai.NewSeededRandom(7).Choose(b.Snapshot(), "playerOne", roll)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Choose() = %v and %v with the same seed", first, second)
+	}
+}
+
+func TestRandom_Choose_Pass(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	// The starting corner is taken, so there is nowhere to open.
+	b.Pieces = []game.Piece{mustNewPiece(t, "playerTwo", 1, 1, 1, 1)}
+
+	if got, ok := ai.NewSeededRandom(1).Choose(b.Snapshot(), "playerOne", game.Roll{Width: 1, Height: 1}); ok {
+		t.Errorf("Choose() = %v, want a pass", got)
+	}
+}
+
+func contains(placements []game.Placement, pl game.Placement) bool {
+	for _, p := range placements {
+		if p == pl {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+// ai package holds computer opponents. They pick their placements with the same rules the engine enforces on
+// everyone else.
+package ai
+
+import (
+	"fmt"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// Strategy decides what a computer player does with their roll.
+type Strategy interface {
+	// Choose returns where the player puts the piece they rolled, or false if they pass. Strategies that want to try
+	// out placements play them on a board from the snapshot.
+	Choose(s game.Snapshot, playerID string, roll game.Roll) (game.Placement, bool)
+}
+
+// Turn plays the current player's turn with the strategy: rolls if they haven't yet, then places the piece where the
+// strategy chooses or passes.
+func Turn(g *game.Game, s Strategy) error {
+	player := g.CurrentPlayer().ID
+
+	switch g.Phase {
+	case game.AwaitingRoll:
+		if _, err := g.Roll(player); err != nil {
+			return fmt.Errorf("ai.Turn(): Roll(): %w", err)
+		}
+		// The roll couldn't be placed anywhere, so the engine passed for them.
+		if g.Phase != game.AwaitingPlacement {
+			return nil
+		}
+	case game.AwaitingPlacement:
+	default:
+		return fmt.Errorf("ai.Turn(): %w", &game.TurnError{Action: "turn", Player: player, Phase: g.Phase, Err: game.ErrWrongPhase})
+	}
+
+	pl, ok := s.Choose(g.Snapshot(), player, *g.Board.Roll)
+	if !ok {
+		if err := g.Pass(player); err != nil {
+			return fmt.Errorf("ai.Turn(): Pass(): %w", err)
+		}
+		return nil
+	}
+
+	if err := g.Place(player, pl.Origin, pl.Rotated); err != nil {
+		return fmt.Errorf("ai.Turn(): Place(): %w", err)
+	}
+	return nil
+}
+
+// best returns the legal placement the evaluation rates highest, the first one on ties, or false if there is none.
+// The evaluation gets the board with the placement already made.
+func best(s game.Snapshot, playerID string, roll game.Roll, evaluate func(b *game.Board) int) (game.Placement, bool) {
+	var chosen game.Placement
+	found := false
+	top := 0
+
+	for _, pl := range s.LegalPlacements(playerID, roll.Width, roll.Height) {
+		after, ok := try(s, playerID, pl)
+		if !ok {
+			continue
+		}
+		if v := evaluate(&after); !found || v > top {
+			chosen, top, found = pl, v, true
+		}
+	}
+
+	return chosen, found
+}
+
+// try returns a board from the snapshot with the placement made.
+func try(s game.Snapshot, playerID string, pl game.Placement) (game.Board, bool) {
+	p, err := pl.Piece(playerID)
+	if err != nil {
+		return game.Board{}, false
+	}
+
+	after := s.Board()
+	after.Roll = nil
+	if _, err := after.PlacePiece(p); err != nil {
+		return game.Board{}, false
+	}
+	return after, true
+}
+
+// score returns the score of the player on the board.
+func score(b *game.Board, playerID string) int {
+	for _, p := range b.Players {
+		if p.ID == playerID {
+			return int(p.Score)
+		}
+	}
+	return 0
+}
@@ -0,0 +1,95 @@
+package ai_test
+
+import (
+	"errors"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/ai"
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// passing always passes.
+type passing struct{}
+
+func (passing) Choose(game.Snapshot, string, game.Roll) (game.Placement, bool) {
+	return game.Placement{}, false
+}
+
+func newGame(t testing.TB, seed int64) game.Game {
+	t.Helper()
+
+	g, err := game.NewWithConfig("ai", game.Config{Seed: seed}, 8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	return g
+}
+
+func mustNewPiece(t *testing.T, player string, x uint8, y uint8, width uint8, height uint8) game.Piece {
+	t.Helper()
+
+	p, err := game.NewPiece(player, x, y, width, height)
+	if err != nil {
+		t.Fatalf("NewPiece() error = %v", err)
+	}
+	return p
+}
+
+func TestTurn(t *testing.T) {
+	tests := []struct {
+		name       string
+		strategies []ai.Strategy
+	}{
+		{
+			name:       "random against random",
+			strategies: []ai.Strategy{ai.NewSeededRandom(1), ai.NewSeededRandom(2)},
+		},
+		{
+			name:       "greedy against heuristic",
+			strategies: []ai.Strategy{ai.Greedy{}, ai.DefaultHeuristic},
+		},
+		{
+			name:       "random against heuristic",
+			strategies: []ai.Strategy{ai.NewSeededRandom(3), ai.DefaultHeuristic},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newGame(t, 42)
+
+			for turn := 0; g.Phase != game.GameOver; turn++ {
+				if turn > 1000 {
+					t.Fatalf("game did not end after %d turns", turn)
+				}
+				if err := ai.Turn(&g, tt.strategies[g.Current]); err != nil {
+					t.Fatalf("Turn() error = %v", err)
+				}
+			}
+
+			if len(g.Board.Pieces) == 0 {
+				t.Errorf("Turn() did not place any pieces")
+			}
+		})
+	}
+}
+
+func TestTurn_Pass(t *testing.T) {
+	g := newGame(t, 42)
+
+	if err := ai.Turn(&g, passing{}); err != nil {
+		t.Fatalf("Turn() error = %v", err)
+	}
+
+	if g.Passes != 1 || g.Current != 1 || g.Phase != game.AwaitingRoll {
+		t.Errorf("Turn() passes = %d, current = %d, phase = %s, want 1, 1, %s", g.Passes, g.Current, g.Phase, game.AwaitingRoll)
+	}
+}
+
+func TestTurn_GameOver(t *testing.T) {
+	g := newGame(t, 42)
+	g.Phase = game.GameOver
+
+	if err := ai.Turn(&g, ai.Greedy{}); !errors.Is(err, game.ErrWrongPhase) {
+		t.Errorf("Turn() error = %v, want %v", err, game.ErrWrongPhase)
+	}
+}
@@ -1,7 +1,6 @@
 package game
 
 import (
-	"errors"
 	"fmt"
 )
 
@@ -10,46 +9,109 @@ const (
 	maxBoardHeight = 30
 	minBoardWidth  = 8
 	maxBoardWidth  = 24
+	// minBoardSide is the shortest a side of any board can be, so the four starting corners are all different cells.
+	minBoardSide = 2
+	minPlayers   = 2
+	maxPlayers   = 4
 )
 
+// Bounds limit the size of boards, inclusive.
+type Bounds struct {
+	MinWidth  uint8
+	MaxWidth  uint8
+	MinHeight uint8
+	MaxHeight uint8
+}
+
+// DefaultBounds are the bounds of the standard game, from 8x12 to 24x30.
+var DefaultBounds = Bounds{MinWidth: minBoardWidth, MaxWidth: maxBoardWidth, MinHeight: minBoardHeight, MaxHeight: maxBoardHeight}
+
+// orDefault returns the bounds, or DefaultBounds if they are the zero value.
+func (bo Bounds) orDefault() Bounds {
+	if bo == (Bounds{}) {
+		return DefaultBounds
+	}
+	return bo
+}
+
+func (bo Bounds) validate() error {
+	if bo.MinWidth < minBoardSide || bo.MinHeight < minBoardSide {
+		return fmt.Errorf("board bounds are too small. Got %dx%d, need to be at least %dx%d", bo.MinWidth, bo.MinHeight, minBoardSide, minBoardSide)
+	}
+	if bo.MinWidth > bo.MaxWidth || bo.MinHeight > bo.MaxHeight {
+		return fmt.Errorf("board bounds are upside down. Got %dx%d to %dx%d", bo.MinWidth, bo.MinHeight, bo.MaxWidth, bo.MaxHeight)
+	}
+	return nil
+}
+
 type Board struct {
 	Width   uint8
 	Height  uint8
 	Players []Player
 	Corners []Coordinate
 	Pieces  []Piece
+	// Roll is the outstanding roll the next placed piece has to match. Nil if nothing has been rolled.
+	Roll *Roll
+	// Scorer keeps the scores of the players up to date. Nil means AreaScorer.
+	Scorer Scorer
+	// Ruleset decides where pieces can be placed. Nil means the standard Rules.
+	Ruleset Ruleset
+	// Map blocks cells of the board. Nil means every cell can be played on.
+	Map *Map
+	// Dice are the faces of the dice rolled for pieces, which limit how large they can be. Nil means D6.
+	Dice Faces
+	// Doubles is the bonus for rolling a double. Only DoublesAnywhere changes where pieces can be placed.
+	Doubles Doubles
+}
+
+// Returns a new instance of a board with given height and width for 2 to 4 players. Players start in the corners in
+// the order they are given: top left, bottom right, top right, then bottom left. The size has to be within
+// DefaultBounds.
+func NewBoard(width uint8, height uint8, players ...string) (Board, error) {
+	return DefaultBounds.NewBoard(width, height, players...)
 }
 
-// Returns a new instance of a board with given height and width.
-func NewBoard(width uint8, height uint8, playerOne string, playerTwo string) (Board, error) {
-	if !isBetween(height, minBoardHeight, maxBoardHeight) {
-		return Board{}, fmt.Errorf("board height is out of bounds. Got %d, need to be between %d and %d inclusive", height, minBoardHeight, maxBoardHeight)
+// NewBoard returns a new board like the package level NewBoard does, with a size within these bounds.
+func (bo Bounds) NewBoard(width uint8, height uint8, players ...string) (Board, error) {
+	if err := bo.validate(); err != nil {
+		return Board{}, err
 	}
-	if !isBetween(width, minBoardWidth, maxBoardWidth) {
-		return Board{}, fmt.Errorf("board width is out of bounds. Got %d, need to be between %d and %d inclusive", width, minBoardWidth, maxBoardWidth)
+	if !isBetween(height, bo.MinHeight, bo.MaxHeight) {
+		return Board{}, fmt.Errorf("board height is out of bounds. Got %d, need to be between %d and %d inclusive", height, bo.MinHeight, bo.MaxHeight)
 	}
-
-	var c []Coordinate
-
-	// Top left corner (start for player 1)
-	c = append(c, Coordinate{X: 1, Y: 1})
-
-	// Bottom right corner (start for player 2)
-	c = append(c, Coordinate{X: width, Y: height})
-
-	p1, err := NewPlayer(playerOne, playerOne)
-	if err != nil {
-		return Board{}, fmt.Errorf("game: NewBoard(): %w", err)
+	if !isBetween(width, bo.MinWidth, bo.MaxWidth) {
+		return Board{}, fmt.Errorf("board width is out of bounds. Got %d, need to be between %d and %d inclusive", width, bo.MinWidth, bo.MaxWidth)
+	}
+	if len(players) < minPlayers || len(players) > maxPlayers {
+		return Board{}, fmt.Errorf("number of players is out of bounds. Got %d, need to be between %d and %d inclusive", len(players), minPlayers, maxPlayers)
 	}
 
-	p2, err := NewPlayer(playerTwo, playerTwo)
-	if err != nil {
-		return Board{}, fmt.Errorf("game: NewBoard(): %w", err)
+	corners := []Coordinate{
+		// Top left corner (start for player 1)
+		{X: 1, Y: 1},
+		// Bottom right corner (start for player 2)
+		{X: width, Y: height},
+		// Top right corner (start for player 3)
+		{X: width, Y: 1},
+		// Bottom left corner (start for player 4)
+		{X: 1, Y: height},
 	}
 
-	players := []Player{p1, p2}
+	var ps []Player
+	for _, name := range players {
+		p, err := NewPlayer(name, name)
+		if err != nil {
+			return Board{}, fmt.Errorf("game: NewBoard(): %w", err)
+		}
+		for _, other := range ps {
+			if other.ID == p.ID {
+				return Board{}, fmt.Errorf("game: NewBoard(): player %q joined twice", p.ID)
+			}
+		}
+		ps = append(ps, p)
+	}
 
-	return Board{Width: width, Height: height, Corners: c, Players: players, Pieces: []Piece{}}, nil
+	return Board{Width: width, Height: height, Corners: corners[:len(ps)], Players: ps, Pieces: []Piece{}}, nil
 }
 
 // Utility function to check whether the board is the right size.
@@ -58,49 +120,186 @@ func isBetween(what uint8, lowerBoundary uint8, upperBoundary uint8) bool {
 }
 
 func (b *Board) canPlacePiece(p Piece) bool {
-	// If any of the coordinates of the new piece are outside of the bounds of the board, it can't be placed.
-	for _, c := range p.Coordinates {
-		if !b.IsCoordinateWithin(c) {
-			return false
+	return b.canPlace(b.occupancyAround(p), &fences{b: b}, p, b.hasPieces(p.Player))
+}
+
+// isPlaying checks whether the player is one of the players of the board.
+func (b *Board) isPlaying(playerID string) bool {
+	for _, player := range b.Players {
+		if player.ID == playerID {
+			return true
 		}
 	}
+	return false
+}
 
-	// If any of the coordinates of the new piece intersect ANY pieces, it can't be placed.
+// hasPieces checks whether the player has placed anything on the board yet.
+func (b *Board) hasPieces(playerID string) bool {
 	for _, piece := range b.Pieces {
-		for _, c := range p.Coordinates {
-			if piece.IsCoordinateWithin(c) {
-				return false
-			}
+		if piece.Player == playerID {
+			return true
+		}
+	}
+	return false
+}
+
+// startingCorner returns the corner assigned to the player. Players get the corners in the order they joined.
+func (b *Board) startingCorner(playerID string) (Coordinate, bool) {
+	for i, player := range b.Players {
+		if player.ID == playerID && i < len(b.Corners) {
+			return b.Corners[i], true
 		}
 	}
+	return Coordinate{}, false
+}
 
-	// If any of the coordinates of the new piece is adjacent to our OWN pieces, it _can_ be placed.
+// CoveredArea returns the number of cells the player's pieces cover.
+func (b *Board) CoveredArea(playerID string) uint {
+	// Adding up the pieces is enough, unless the ruleset lets them overlap.
+	if r, ok := b.ruleset().(Rules); !ok || r.OverlapOwn {
+		return b.occupancy().area(playerID)
+	}
+
+	var area uint
 	for _, piece := range b.Pieces {
-		if p.Player != piece.Player {
-			continue
+		if piece.Player == playerID {
+			area += uint(piece.Width) * uint(piece.Height)
 		}
-		for _, c := range p.Coordinates {
-			if piece.IsAdjacent(c) {
-				return true
+	}
+	return area
+}
+
+// FreeCells returns the number of cells on the board not covered by any piece or blocked by the map.
+func (b *Board) FreeCells() uint {
+	g := b.occupancy()
+
+	var free uint
+	for y := 1; y <= g.height; y++ {
+		for x := 1; x <= g.width; x++ {
+			if b.isOpen(g, x, y) {
+				free++
 			}
 		}
 	}
-
-	return false
+	return free
 }
 
+// IsCoordinateWithin checks whether the cell is on the board and not blocked by its map.
 func (b *Board) IsCoordinateWithin(c Coordinate) bool {
 	if c.X < 1 || c.X > b.Width || c.Y < 1 || c.Y > b.Height {
 		return false
 	}
-	return true
+	return b.Map == nil || !b.Map.Blocked(c)
 }
 
+// RollDice rolls the dice and records the outcome as the outstanding roll for the next piece.
+func (b *Board) RollDice(r Roller) Roll {
+	roll := r.Roll()
+	b.Roll = &roll
+	return roll
+}
+
+// PlacePiece puts the piece on the board and uses up the roll. A piece that can't be placed leaves the board as it was
+// and returns a *PlacementError saying why.
 func (b *Board) PlacePiece(p Piece) (*Board, error) {
+	// Boards put together without players take pieces of anyone.
+	if len(b.Players) > 0 && !b.isPlaying(p.Player) {
+		return b, &PlacementError{Piece: p, Err: ErrWrongPlayer, At: p.Origin}
+	}
+
+	if max := b.dice().Max(); p.Width > max || p.Height > max {
+		return b, &PlacementError{Piece: p, Err: fmt.Errorf("%w: the dice only go up to %d", ErrWrongDimensions, max), At: p.Origin}
+	}
+
+	if b.Roll != nil && !b.Roll.Fits(p.Width, p.Height) {
+		return b, &PlacementError{Piece: p, Err: fmt.Errorf("%w: the roll was %dx%d", ErrWrongDimensions, b.Roll.Width, b.Roll.Height), At: p.Origin}
+	}
+
 	if !b.canPlacePiece(p) {
-		return b, errors.New("can't place piece there")
+		return b, b.placementError(p)
 	}
 
 	b.Pieces = append(b.Pieces, p)
+	b.Roll = nil
+	b.updateScores()
 	return b, nil
 }
+
+func (b *Board) dice() Faces {
+	return b.Dice.orD6()
+}
+
+// Clone returns a deep copy of the board that can be changed without affecting the original, eg to try out
+// placements. The map, scorer and ruleset are shared, the package never changes them.
+func (b Board) Clone() Board {
+	c := b
+	c.Players = append([]Player(nil), b.Players...)
+	c.Corners = append([]Coordinate(nil), b.Corners...)
+	c.Pieces = clonePieces(b.Pieces)
+	c.Dice = append(Faces(nil), b.Dice...)
+	if b.Roll != nil {
+		roll := *b.Roll
+		c.Roll = &roll
+	}
+	return c
+}
+
+// clonePieces returns a deep copy of the pieces. The cells of all of them share a single allocation, so cloning stays
+// cheap for searches that clone boards by the thousand.
+func clonePieces(pieces []Piece) []Piece {
+	size := 0
+	for _, p := range pieces {
+		size += len(p.AdjacentFields) + len(p.Corners) + len(p.Coordinates)
+	}
+
+	cells := make([]Coordinate, 0, size)
+	cloned := make([]Piece, len(pieces))
+	for i, p := range pieces {
+		cloned[i] = p
+		cloned[i].AdjacentFields, cells = cloneCells(p.AdjacentFields, cells)
+		cloned[i].Corners, cells = cloneCells(p.Corners, cells)
+		cloned[i].Coordinates, cells = cloneCells(p.Coordinates, cells)
+	}
+	return cloned
+}
+
+// cloneCells copies the coordinates to the end of cells, and returns the copy and cells grown by it. The copy is capped
+// so appending to it can't spill over into the next one.
+func cloneCells(coordinates []Coordinate, cells []Coordinate) ([]Coordinate, []Coordinate) {
+	if coordinates == nil {
+		return nil, cells
+	}
+	at := len(cells)
+	cells = append(cells, coordinates...)
+	return cells[at:len(cells):len(cells)], cells
+}
+
+// isOpen checks whether a cell of the board can still be placed on: it's free and not blocked by the map.
+func (b *Board) isOpen(g grid, x int, y int) bool {
+	return g.isFree(x, y) && (b.Map == nil || !b.Map.Blocked(Coordinate{X: uint8(x), Y: uint8(y)}))
+}
+
+// Frontier returns the number of free cells the player can grow into: the ones along the edges of their pieces, or
+// their starting corner if they haven't placed anything yet.
+func (b *Board) Frontier(playerID string) uint {
+	g := b.occupancy()
+
+	if !b.hasPieces(playerID) {
+		corner, ok := b.startingCorner(playerID)
+		if ok && b.isOpen(g, int(corner.X), int(corner.Y)) {
+			return 1
+		}
+		return 0
+	}
+
+	var frontier uint
+	for y := 1; y <= g.height; y++ {
+		for x := 1; x <= g.width; x++ {
+			if b.isOpen(g, x, y) && (g.owner(x-1, y) == playerID || g.owner(x+1, y) == playerID ||
+				g.owner(x, y-1) == playerID || g.owner(x, y+1) == playerID) {
+				frontier++
+			}
+		}
+	}
+	return frontier
+}
@@ -132,6 +132,70 @@ func TestNewBoard(t *testing.T) {
 	}
 }
 
+func TestNewBoard_Players(t *testing.T) {
+	tests := []struct {
+		name        string
+		players     []string
+		wantCorners []game.Coordinate
+		wantErr     bool
+	}{
+		{
+			name:    "three players, third starts top right",
+			players: []string{"playerOne", "playerTwo", "playerThree"},
+			wantCorners: []game.Coordinate{
+				{X: 1, Y: 1},
+				{X: 12, Y: 16},
+				{X: 12, Y: 1},
+			},
+		},
+		{
+			name:    "four players, fourth starts bottom left",
+			players: []string{"playerOne", "playerTwo", "playerThree", "playerFour"},
+			wantCorners: []game.Coordinate{
+				{X: 1, Y: 1},
+				{X: 12, Y: 16},
+				{X: 12, Y: 1},
+				{X: 1, Y: 16},
+			},
+		},
+		{
+			name:    "Error, one player",
+			players: []string{"playerOne"},
+			wantErr: true,
+		},
+		{
+			name:    "Error, five players",
+			players: []string{"playerOne", "playerTwo", "playerThree", "playerFour", "playerFive"},
+			wantErr: true,
+		},
+		{
+			name:    "Error, same player twice",
+			players: []string{"playerOne", "playerTwo", "playerOne"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := game.NewBoard(12, 16, tt.players...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBoard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got.Corners, tt.wantCorners) {
+				t.Errorf("NewBoard() corners = %v, want %v", got.Corners, tt.wantCorners)
+			}
+			for i, p := range got.Players {
+				if p.ID != tt.players[i] {
+					t.Errorf("NewBoard() player %d = %s, want %s", i, p.ID, tt.players[i])
+				}
+			}
+		})
+	}
+}
+
 func TestBoard_PlacePiece(t *testing.T) {
 	piece1 := game.Piece{
 		Player: "id-player-1",
@@ -714,3 +778,402 @@ func TestBoard_IsCoordinateWithin(t *testing.T) {
 		})
 	}
 }
+
+func TestBoard_PlacePiece_Roll(t *testing.T) {
+	own, err := game.NewPiece("id-player-1", 1, 1, 2, 2)
+	if err != nil {
+		t.Fatalf("NewPiece() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		roll    *game.Roll
+		width   uint8
+		height  uint8
+		wantErr bool
+	}{
+		{
+			name:    "can place piece without an outstanding roll",
+			roll:    nil,
+			width:   3,
+			height:  2,
+			wantErr: false,
+		},
+		{
+			name:    "can place piece matching the roll",
+			roll:    &game.Roll{Width: 3, Height: 2},
+			width:   3,
+			height:  2,
+			wantErr: false,
+		},
+		{
+			name:    "can not place piece not matching the roll",
+			roll:    &game.Roll{Width: 3, Height: 2},
+			width:   4,
+			height:  2,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := game.Board{
+				Width:  8,
+				Height: 12,
+				Pieces: []game.Piece{own},
+				Roll:   tt.roll,
+			}
+
+			p, err := game.NewPiece("id-player-1", 3, 1, tt.width, tt.height)
+			if err != nil {
+				t.Fatalf("NewPiece() error = %v", err)
+			}
+
+			_, err = b.PlacePiece(p)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PlacePiece() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr && b.Roll != tt.roll {
+				t.Errorf("PlacePiece() consumed the roll on error")
+			}
+			if !tt.wantErr && b.Roll != nil {
+				t.Errorf("PlacePiece() did not consume the roll, got %v", *b.Roll)
+			}
+		})
+	}
+}
+
+func TestBoard_RollDice(t *testing.T) {
+	b := game.Board{Width: 8, Height: 12}
+
+	got := b.RollDice(game.NewSeededDice(7))
+
+	if b.Roll == nil || *b.Roll != got {
+		t.Errorf("RollDice() outstanding roll = %v, want %v", b.Roll, got)
+	}
+}
+
+func TestBoard_PlacePiece_Opening(t *testing.T) {
+	type placement struct {
+		player  string
+		origin  game.Coordinate
+		width   uint8
+		height  uint8
+		wantErr bool
+	}
+	tests := []struct {
+		name  string
+		moves []placement
+	}{
+		{
+			name: "player one opens in the top left corner",
+			moves: []placement{
+				{player: "playerOne", origin: game.Coordinate{X: 1, Y: 1}, width: 3, height: 2},
+			},
+		},
+		{
+			name: "player two opens in the bottom right corner",
+			moves: []placement{
+				{player: "playerTwo", origin: game.Coordinate{X: 10, Y: 15}, width: 3, height: 2},
+			},
+		},
+		{
+			name: "both players open, then grow from their own pieces",
+			moves: []placement{
+				{player: "playerOne", origin: game.Coordinate{X: 1, Y: 1}, width: 2, height: 2},
+				{player: "playerTwo", origin: game.Coordinate{X: 12, Y: 16}, width: 1, height: 1},
+				{player: "playerOne", origin: game.Coordinate{X: 3, Y: 1}, width: 4, height: 1},
+				{player: "playerTwo", origin: game.Coordinate{X: 7, Y: 16}, width: 5, height: 1},
+			},
+		},
+		{
+			name: "opening piece has to cover the corner",
+			moves: []placement{
+				{player: "playerOne", origin: game.Coordinate{X: 2, Y: 1}, width: 3, height: 2, wantErr: true},
+			},
+		},
+		{
+			name: "can not open in the other player's corner",
+			moves: []placement{
+				{player: "playerTwo", origin: game.Coordinate{X: 1, Y: 1}, width: 3, height: 2, wantErr: true},
+			},
+		},
+		{
+			name: "unknown players have no corner",
+			moves: []placement{
+				{player: "playerThree", origin: game.Coordinate{X: 1, Y: 1}, width: 3, height: 2, wantErr: true},
+			},
+		},
+		{
+			name: "corner does not count after the opening",
+			moves: []placement{
+				{player: "playerOne", origin: game.Coordinate{X: 1, Y: 1}, width: 2, height: 2},
+				{player: "playerOne", origin: game.Coordinate{X: 5, Y: 5}, width: 2, height: 2, wantErr: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(12, 16, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+
+			for i, m := range tt.moves {
+				p, err := game.NewPiece(m.player, m.origin.X, m.origin.Y, m.width, m.height)
+				if err != nil {
+					t.Fatalf("NewPiece() error = %v", err)
+				}
+
+				if _, err := b.PlacePiece(p); (err != nil) != m.wantErr {
+					t.Errorf("PlacePiece() move %d error = %v, wantErr %v", i, err, m.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestBoard_Clone(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "id-player-1", "id-player-2")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	b.Pieces = append(b.Pieces, mustNewPiece(t, "id-player-2", 8, 12, 1, 1))
+	b.Roll = &game.Roll{Width: 1, Height: 1}
+	want := b.Clone()
+
+	c := b.Clone()
+	if !reflect.DeepEqual(c, b) {
+		t.Fatalf("Clone() = %v, want %v", c, b)
+	}
+
+	p, err := game.NewPiece("id-player-1", 1, 1, 1, 1)
+	if err != nil {
+		t.Fatalf("NewPiece() error = %v", err)
+	}
+	if _, err := c.PlacePiece(p); err != nil {
+		t.Fatalf("PlacePiece() error = %v", err)
+	}
+	c.Players[1].Name = "changed"
+	c.Corners[1] = game.Coordinate{X: 2, Y: 2}
+	c.Pieces[0].Coordinates[0] = game.Coordinate{X: 3, Y: 3}
+	c.Pieces[0].Corners = append(c.Pieces[0].Corners, game.Coordinate{X: 4, Y: 4})
+
+	if !reflect.DeepEqual(b, want) {
+		t.Errorf("changing the clone changed the original board, got %v, want %v", b, want)
+	}
+}
+
+func TestBoard_Frontier(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces []game.Piece
+		player string
+		want   uint
+	}{
+		{
+			name:   "starting corner before the first piece",
+			pieces: []game.Piece{},
+			player: "id-player-1",
+			want:   1,
+		},
+		{
+			name:   "piece in the corner",
+			pieces: []game.Piece{mustNewPiece(t, "id-player-1", 1, 1, 2, 3)},
+			player: "id-player-1",
+			want:   5,
+		},
+		{
+			name: "cells covered by others are not free",
+			pieces: []game.Piece{
+				mustNewPiece(t, "id-player-1", 1, 1, 2, 3),
+				mustNewPiece(t, "id-player-2", 3, 1, 1, 1),
+			},
+			player: "id-player-1",
+			want:   4,
+		},
+		{
+			name:   "starting corner taken by someone else",
+			pieces: []game.Piece{mustNewPiece(t, "id-player-2", 1, 1, 1, 1)},
+			player: "id-player-1",
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "id-player-1", "id-player-2")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Pieces = tt.pieces
+
+			if got := b.Frontier(tt.player); got != tt.want {
+				t.Errorf("Frontier() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBounds_NewBoard(t *testing.T) {
+	small := game.Bounds{MinWidth: 4, MaxWidth: 6, MinHeight: 4, MaxHeight: 6}
+
+	tests := []struct {
+		name    string
+		bounds  game.Bounds
+		width   uint8
+		height  uint8
+		wantErr bool
+	}{
+		{name: "small board within small bounds", bounds: small, width: 4, height: 6, wantErr: false},
+		{name: "too narrow for small bounds", bounds: small, width: 3, height: 4, wantErr: true},
+		{name: "too high for small bounds", bounds: small, width: 4, height: 7, wantErr: true},
+		{name: "default bounds", bounds: game.DefaultBounds, width: 4, height: 4, wantErr: true},
+		{
+			name:    "marathon board",
+			bounds:  game.Bounds{MinWidth: 24, MaxWidth: 200, MinHeight: 30, MaxHeight: 200},
+			width:   120,
+			height:  160,
+			wantErr: false,
+		},
+		{
+			name:    "bounds upside down",
+			bounds:  game.Bounds{MinWidth: 6, MaxWidth: 4, MinHeight: 4, MaxHeight: 6},
+			width:   5,
+			height:  5,
+			wantErr: true,
+		},
+		{
+			name:    "bounds too small for four corners",
+			bounds:  game.Bounds{MinWidth: 1, MaxWidth: 4, MinHeight: 1, MaxHeight: 4},
+			width:   2,
+			height:  2,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := tt.bounds.NewBoard(tt.width, tt.height, "id-player-1", "id-player-2")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewBoard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (b.Width != tt.width || b.Height != tt.height) {
+				t.Errorf("NewBoard() size = %dx%d, want %dx%d", b.Width, b.Height, tt.width, tt.height)
+			}
+		})
+	}
+}
+
+func TestBoard_PlacePiece_Dice(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "id-player-1", "id-player-2")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	big, err := game.D8.NewPiece("id-player-1", 1, 1, 7, 8)
+	if err != nil {
+		t.Fatalf("NewPiece() error = %v", err)
+	}
+
+	if _, err := b.PlacePiece(big); err == nil {
+		t.Errorf("PlacePiece() placed a 7x8 piece with six sided dice")
+	}
+	if got := b.LegalPlacements("id-player-1", 7, 8); got != nil {
+		t.Errorf("LegalPlacements() of 7x8 with six sided dice = %v, want none", got)
+	}
+
+	b.Dice = game.D8
+	if got := b.LegalPlacements("id-player-1", 7, 8); len(got) != 2 {
+		t.Errorf("LegalPlacements() of 7x8 with eight sided dice = %v, want the corner both ways", got)
+	}
+	if _, err := b.PlacePiece(big); err != nil {
+		t.Errorf("PlacePiece() error = %v", err)
+	}
+}
+
+// benchmarkBoard returns the largest board with both players having played the same seeded sequence of rolls, each
+// taking the first legal placement, for as many turns as they could.
+func benchmarkBoard(b *testing.B, turns int) game.Board {
+	b.Helper()
+
+	board, err := game.NewBoard(24, 30, "playerOne", "playerTwo")
+	if err != nil {
+		b.Fatalf("NewBoard() error = %v", err)
+	}
+
+	dice := game.NewSeededDice(1)
+	for i := 0; i < turns; i++ {
+		player := board.Players[i%len(board.Players)].ID
+		roll := board.RollDice(dice)
+
+		legal := board.LegalPlacements(player, roll.Width, roll.Height)
+		if len(legal) == 0 {
+			board.Roll = nil
+			continue
+		}
+
+		p, err := legal[len(legal)/2].Piece(player)
+		if err != nil {
+			b.Fatalf("Piece() error = %v", err)
+		}
+		if _, err := board.PlacePiece(p); err != nil {
+			b.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+
+	return board
+}
+
+func BenchmarkBoard_PlacePiece(b *testing.B) {
+	board := benchmarkBoard(b, 60)
+	legal := board.LegalPlacements("playerOne", 2, 3)
+	if len(legal) == 0 {
+		b.Fatalf("LegalPlacements() found nothing")
+	}
+	p, err := legal[0].Piece("playerOne")
+	if err != nil {
+		b.Fatalf("Piece() error = %v", err)
+	}
+
+	pieces := make([]game.Piece, len(board.Pieces), len(board.Pieces)+1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		local := board
+		local.Pieces = pieces[:copy(pieces, board.Pieces)]
+		if _, err := local.PlacePiece(p); err != nil {
+			b.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+}
+
+func BenchmarkBoard_LegalPlacements(b *testing.B) {
+	board := benchmarkBoard(b, 60)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.LegalPlacements("playerOne", 2, 3)
+	}
+}
+
+func BenchmarkBoard_LegalPlacements_ProtectTerritory(b *testing.B) {
+	board := benchmarkBoard(b, 60)
+	board.Ruleset = game.Rules{ProtectTerritory: true}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		board.LegalPlacements("playerOne", 2, 3)
+	}
+}
+
+func BenchmarkBoard_PlacePiece_Illegal(b *testing.B) {
+	board := benchmarkBoard(b, 60)
+	p, err := game.NewPiece("playerOne", 12, 15, 2, 3)
+	if err != nil {
+		b.Fatalf("NewPiece() error = %v", err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := board.PlacePiece(p); err == nil {
+			b.Fatalf("PlacePiece() placed a piece that should not fit")
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrOutOfTime is returned when a player tries to act after their time ran out.
+var ErrOutOfTime = errors.New("out of time")
+
+// Clock tells the time. Games use it to keep track of how long players take for their turns.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the wall clock of the machine.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// Timeout is what happens to a player who runs out of time.
+type Timeout uint8
+
+const (
+	// TimeoutPass makes the player pass their turn.
+	TimeoutPass Timeout = iota
+	// TimeoutForfeit takes the player out of the game, which they lose. The game ends when only one player is left.
+	TimeoutForfeit
+)
+
+func (t Timeout) String() string {
+	switch t {
+	case TimeoutPass:
+		return "pass"
+	case TimeoutForfeit:
+		return "forfeit"
+	default:
+		return fmt.Sprintf("unknown timeout %d", uint8(t))
+	}
+}
+
+// TimeControl limits how long players can take. The zero value means they can take as long as they like.
+//
+// With only a Base it's sudden death: every player has that much time for the whole game. An Increment on top makes it
+// Fischer timing, where players get the increment back after each of their turns. PerMove limits every turn on its
+// own, and can be combined with the others.
+type TimeControl struct {
+	// Base is the time every player has for the whole game. Zero means there is no limit for the whole game.
+	Base time.Duration
+	// Increment is added to the time of a player after each of their turns. It needs a Base.
+	Increment time.Duration
+	// PerMove is the time a player has for a turn, from when it starts until they place or pass. Zero means no limit.
+	PerMove time.Duration
+	// OnTimeout is what happens when a player runs out of time.
+	OnTimeout Timeout
+}
+
+func (tc TimeControl) enabled() bool {
+	return tc.Base > 0 || tc.PerMove > 0
+}
+
+func (tc TimeControl) validate() error {
+	if tc.Base < 0 || tc.Increment < 0 || tc.PerMove < 0 {
+		return fmt.Errorf("time control %v has a negative duration", tc)
+	}
+	if tc.Increment > 0 && tc.Base == 0 {
+		return fmt.Errorf("time control has an increment of %s, but no base time", tc.Increment)
+	}
+	if tc.OnTimeout > TimeoutForfeit {
+		return fmt.Errorf("time control has an %s", tc.OnTimeout)
+	}
+	return nil
+}
+
+// TimeLeft returns how long the current player has left for their turn: the shorter of their time for the rest of the
+// game and the per move limit. It's false if the game has no time control or is over.
+func (g *Game) TimeLeft() (time.Duration, bool) {
+	if !g.Config.Time.enabled() || g.Phase == GameOver {
+		return 0, false
+	}
+	return g.timeLeft(g.clock().Now()), true
+}
+
+// Tick makes the current player run out of time if their time is up, and reports whether they did. Servers call it
+// regularly, so a player who stalls doesn't hold up the game. The clock of the first player starts when the game is
+// made.
+func (g *Game) Tick() bool {
+	if !g.Config.Time.enabled() || g.Phase == GameOver {
+		return false
+	}
+
+	now := g.clock().Now()
+	if g.timeLeft(now) > 0 {
+		return false
+	}
+
+	player := g.Current
+	if err := g.play(Move{Kind: MoveTimeout, Player: g.CurrentPlayer().ID}); err != nil {
+		return false
+	}
+	g.stopClock(player, now)
+	return true
+}
+
+// clocked makes a move of the player on the clock: a player who ran out of time can't make it any more, and a move
+// that ends their turn stops their clock.
+func (g *Game) clocked(action string, playerID string, move func() error) error {
+	if !g.Config.Time.enabled() {
+		return move()
+	}
+
+	if current := g.CurrentPlayer().ID; g.Tick() && current == playerID {
+		return &TurnError{Action: action, Player: playerID, Phase: g.Phase, Err: ErrOutOfTime}
+	}
+
+	player := g.Current
+	if err := move(); err != nil {
+		return err
+	}
+	if g.Phase != AwaitingPlacement {
+		g.stopClock(player, g.clock().Now())
+	}
+	return nil
+}
+
+// timeLeft returns how long the current player has left for their turn at the time given.
+func (g *Game) timeLeft(now time.Time) time.Duration {
+	tc := g.Config.Time
+
+	elapsed := now.Sub(g.turnStarted)
+
+	left := time.Duration(-1)
+	if tc.Base > 0 {
+		left = g.Remaining[g.Current] - elapsed
+	}
+	if tc.PerMove > 0 && (left < 0 || tc.PerMove-elapsed < left) {
+		left = tc.PerMove - elapsed
+	}
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// stopClock charges the player for their turn that ended at the time given, and starts the clock of the next turn.
+func (g *Game) stopClock(player int, now time.Time) {
+	if tc := g.Config.Time; tc.Base > 0 {
+		left := g.Remaining[player] - now.Sub(g.turnStarted)
+		if left < 0 {
+			left = 0
+		}
+		g.Remaining[player] = left + tc.Increment
+	}
+	g.turnStarted = now
+}
+
+// restartClock starts the clock of the current player over, for when moves were taken back or played again.
+func (g *Game) restartClock() {
+	if g.Config.Time.enabled() {
+		g.turnStarted = g.clock().Now()
+	}
+}
+
+func (g *Game) clock() Clock {
+	if g.Config.Clock == nil {
+		return systemClock{}
+	}
+	return g.Config.Clock
+}
+
+// timeout makes the current player run out of time.
+func (g *Game) timeout(playerID string) error {
+	if g.Phase == GameOver {
+		return &TurnError{Action: "time out", Player: playerID, Phase: g.Phase, Err: ErrWrongPhase}
+	}
+	if g.CurrentPlayer().ID != playerID {
+		return &TurnError{Action: "time out", Player: playerID, Phase: g.Phase, Err: ErrNotYourTurn}
+	}
+
+	g.Board.Roll = nil
+	if g.Config.Time.OnTimeout == TimeoutForfeit {
+		// The player isn't counted as forfeited until the move is in the history.
+		if g.playing()-1 <= 1 {
+			g.end(EndForfeit)
+			return nil
+		}
+		g.endTurn()
+		return nil
+	}
+
+	g.Passes++
+	if g.Passes >= g.Config.passLimit(g.playing()) {
+		g.end(EndPassLimit)
+		return nil
+	}
+
+	g.endTurn()
+	return nil
+}
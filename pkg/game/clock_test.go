@@ -0,0 +1,450 @@
+package game_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// fakeClock only moves when it's told to.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTimedGame returns a game on a 12x16 board with the time control, whose dice roll 2x3 over and over, and the clock
+// it's timed with. The clock of the first player has started.
+func newTimedGame(t *testing.T, tc game.TimeControl) (game.Game, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	g, err := game.NewWithConfig("1", game.Config{Time: tc, Clock: clock}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	return g, clock
+}
+
+func TestNewWithConfig_TimeControl(t *testing.T) {
+	tests := []struct {
+		name          string
+		tc            game.TimeControl
+		wantRemaining []time.Duration
+		wantErr       bool
+	}{
+		{
+			name: "no time control",
+		},
+		{
+			name:          "sudden death",
+			tc:            game.TimeControl{Base: time.Minute},
+			wantRemaining: []time.Duration{time.Minute, time.Minute},
+		},
+		{
+			name: "per move only",
+			tc:   game.TimeControl{PerMove: time.Minute},
+		},
+		{
+			name:    "increment without base time",
+			tc:      game.TimeControl{Increment: time.Second, PerMove: time.Minute},
+			wantErr: true,
+		},
+		{
+			name:    "negative time",
+			tc:      game.TimeControl{Base: -time.Minute},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.NewWithConfig("1", game.Config{Time: tt.tc}, 12, 16, "playerOne", "playerTwo")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(g.Remaining, tt.wantRemaining) {
+				t.Errorf("NewWithConfig() remaining = %v, want %v", g.Remaining, tt.wantRemaining)
+			}
+		})
+	}
+}
+
+func TestGame_Tick(t *testing.T) {
+	tests := []struct {
+		name        string
+		tc          game.TimeControl
+		elapsed     time.Duration
+		want        bool
+		wantCurrent int
+		wantPhase   game.Phase
+		wantEnded   game.EndReason
+	}{
+		{
+			name:        "sudden death with time left",
+			tc:          game.TimeControl{Base: 10 * time.Second},
+			elapsed:     9 * time.Second,
+			want:        false,
+			wantCurrent: 0,
+			wantPhase:   game.AwaitingRoll,
+		},
+		{
+			name:        "sudden death out of time passes",
+			tc:          game.TimeControl{Base: 10 * time.Second},
+			elapsed:     10 * time.Second,
+			want:        true,
+			wantCurrent: 1,
+			wantPhase:   game.AwaitingRoll,
+		},
+		{
+			name:        "per move limit passes",
+			tc:          game.TimeControl{Base: time.Hour, PerMove: 5 * time.Second},
+			elapsed:     6 * time.Second,
+			want:        true,
+			wantCurrent: 1,
+			wantPhase:   game.AwaitingRoll,
+		},
+		{
+			name:        "out of time forfeits",
+			tc:          game.TimeControl{PerMove: 5 * time.Second, OnTimeout: game.TimeoutForfeit},
+			elapsed:     5 * time.Second,
+			want:        true,
+			wantCurrent: 0,
+			wantPhase:   game.GameOver,
+			wantEnded:   game.EndForfeit,
+		},
+		{
+			name:        "no time control",
+			elapsed:     time.Hour,
+			want:        false,
+			wantCurrent: 0,
+			wantPhase:   game.AwaitingRoll,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, clock := newTimedGame(t, tt.tc)
+
+			clock.advance(tt.elapsed)
+			if got := g.Tick(); got != tt.want {
+				t.Errorf("Tick() got = %v, want %v", got, tt.want)
+			}
+			if g.Current != tt.wantCurrent || g.Phase != tt.wantPhase || g.Ended != tt.wantEnded {
+				t.Errorf("Tick() got current = %d, phase = %v, ended = %v, want %d, %v, %v", g.Current, g.Phase, g.Ended, tt.wantCurrent, tt.wantPhase, tt.wantEnded)
+			}
+			if tt.want {
+				if last := g.History[len(g.History)-1]; last.Kind != game.MoveTimeout || last.Player != "playerOne" {
+					t.Errorf("Tick() got last move %v", last)
+				}
+			}
+		})
+	}
+}
+
+func TestGame_Tick_Forfeit(t *testing.T) {
+	g, clock := newTimedGame(t, game.TimeControl{Base: time.Minute, OnTimeout: game.TimeoutForfeit})
+
+	// Player one is ahead, but forfeits on their next turn.
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Pass("playerTwo"); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	clock.advance(time.Minute)
+
+	err := g.Pass("playerOne")
+	if !errors.Is(err, game.ErrOutOfTime) {
+		t.Fatalf("Pass() error = %v, want %v", err, game.ErrOutOfTime)
+	}
+
+	got, err := g.Result()
+	if err != nil {
+		t.Fatalf("Result() error = %v", err)
+	}
+	if got.Winner != "playerTwo" || got.Reason != game.EndForfeit {
+		t.Errorf("Result() got winner %q, reason %v, want %q, %v", got.Winner, got.Reason, "playerTwo", game.EndForfeit)
+	}
+}
+
+func TestGame_Tick_ForfeitPlayers(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	config := game.Config{PassLimit: 10, Time: game.TimeControl{PerMove: 10 * time.Second, OnTimeout: game.TimeoutForfeit}, Clock: clock}
+	g, err := game.NewWithConfig("1", config, 12, 16, "playerOne", "playerTwo", "playerThree", "playerFour")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	timeout := func(player string, wantCurrent string) {
+		t.Helper()
+		clock.advance(10 * time.Second)
+		if !g.Tick() {
+			t.Fatalf("Tick() did not time out %s", player)
+		}
+		if g.Phase == game.GameOver || g.CurrentPlayer().ID != wantCurrent {
+			t.Fatalf("after %s forfeited got phase %v, current %s, want %s to play", player, g.Phase, g.CurrentPlayer().ID, wantCurrent)
+		}
+	}
+	pass := func(player string, wantCurrent string) {
+		t.Helper()
+		if _, err := g.Roll(player); err != nil {
+			t.Fatalf("Roll() error = %v", err)
+		}
+		if err := g.Pass(player); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+		if got := g.CurrentPlayer().ID; got != wantCurrent {
+			t.Fatalf("after %s passed got current %s, want %s", player, got, wantCurrent)
+		}
+	}
+
+	timeout("playerOne", "playerTwo")
+	pass("playerTwo", "playerThree")
+	timeout("playerThree", "playerFour")
+	// The players who forfeited are skipped.
+	pass("playerFour", "playerTwo")
+
+	// The game ends once only one player is left, who wins it.
+	clock.advance(10 * time.Second)
+	if !g.Tick() {
+		t.Fatalf("Tick() did not time out playerTwo")
+	}
+	got, err := g.Result()
+	if err != nil {
+		t.Fatalf("Result() error = %v", err)
+	}
+	if got.Winner != "playerFour" || got.Reason != game.EndForfeit {
+		t.Errorf("Result() got winner %q, reason %v, want %q, %v", got.Winner, got.Reason, "playerFour", game.EndForfeit)
+	}
+}
+
+func TestGame_Clock_Fischer(t *testing.T) {
+	g, clock := newTimedGame(t, game.TimeControl{Base: 10 * time.Second, Increment: 2 * time.Second})
+
+	clock.advance(3 * time.Second)
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if left, _ := g.TimeLeft(); left != 7*time.Second {
+		t.Errorf("TimeLeft() during the turn got = %v, want %v", left, 7*time.Second)
+	}
+	clock.advance(time.Second)
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+
+	// Player one took 4 seconds and got 2 back, player two's clock is running now.
+	clock.advance(5 * time.Second)
+	want := []time.Duration{8 * time.Second, 10 * time.Second}
+	if !reflect.DeepEqual(g.Remaining, want) {
+		t.Errorf("Remaining got = %v, want %v", g.Remaining, want)
+	}
+	if left, ok := g.TimeLeft(); !ok || left != 5*time.Second {
+		t.Errorf("TimeLeft() got = %v, %v, want %v", left, ok, 5*time.Second)
+	}
+}
+
+func TestGame_Clock_OutOfTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		player      string
+		wantErr     error
+		wantCurrent int
+		wantPhase   game.Phase
+	}{
+		{
+			name:        "player who ran out can't act",
+			player:      "playerOne",
+			wantErr:     game.ErrOutOfTime,
+			wantCurrent: 1,
+			wantPhase:   game.AwaitingRoll,
+		},
+		{
+			name:        "next player can act right away",
+			player:      "playerTwo",
+			wantCurrent: 1,
+			wantPhase:   game.AwaitingPlacement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, clock := newTimedGame(t, game.TimeControl{PerMove: 30 * time.Second})
+
+			clock.advance(time.Minute)
+			if _, err := g.Roll(tt.player); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Roll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if g.Current != tt.wantCurrent || g.Phase != tt.wantPhase {
+				t.Errorf("Roll() got current = %d, phase = %v, want %d, %v", g.Current, g.Phase, tt.wantCurrent, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestGame_TimeLeft(t *testing.T) {
+	tests := []struct {
+		name     string
+		tc       game.TimeControl
+		elapsed  time.Duration
+		want     time.Duration
+		wantOk   bool
+		wantOver bool
+	}{
+		{
+			name:    "no time control",
+			elapsed: time.Second,
+		},
+		{
+			name:    "time for the game is shorter",
+			tc:      game.TimeControl{Base: 10 * time.Second, PerMove: time.Minute},
+			elapsed: time.Second,
+			want:    9 * time.Second,
+			wantOk:  true,
+		},
+		{
+			name:    "per move limit is shorter",
+			tc:      game.TimeControl{Base: time.Hour, PerMove: 10 * time.Second},
+			elapsed: time.Second,
+			want:    9 * time.Second,
+			wantOk:  true,
+		},
+		{
+			name:    "ran out",
+			tc:      game.TimeControl{PerMove: 10 * time.Second},
+			elapsed: time.Minute,
+			want:    0,
+			wantOk:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, clock := newTimedGame(t, tt.tc)
+
+			clock.advance(tt.elapsed)
+			if got, ok := g.TimeLeft(); got != tt.want || ok != tt.wantOk {
+				t.Errorf("TimeLeft() got = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestGame_MarshalJSON_Clock(t *testing.T) {
+	g, clock := newTimedGame(t, game.TimeControl{Base: 10 * time.Second, PerMove: 5 * time.Second})
+	clock.advance(3 * time.Second)
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var state struct {
+		Clock struct {
+			Remaining []int64 `json:"remainingMs"`
+			TurnLeft  int64   `json:"turnLeftMs"`
+		} `json:"clock"`
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if want := []int64{7000, 10000}; !reflect.DeepEqual(state.Clock.Remaining, want) || state.Clock.TurnLeft != 2000 {
+		t.Errorf("Marshal() got clock %+v, want remaining %v and turn left %d", state.Clock, want, 2000)
+	}
+
+	var got game.Game
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	// The turn in progress carries on: it isn't taken off the remaining time until it ends.
+	if want := []time.Duration{10 * time.Second, 10 * time.Second}; !reflect.DeepEqual(got.Remaining, want) {
+		t.Errorf("Unmarshal() got remaining %v, want %v", got.Remaining, want)
+	}
+	got.Config.Clock = clock
+	if left, ok := got.TimeLeft(); left != 2*time.Second || !ok {
+		t.Errorf("TimeLeft() got = %v, %v, want %v, %v", left, ok, 2*time.Second, true)
+	}
+
+	clock.advance(2 * time.Second)
+	if !got.Tick() {
+		t.Errorf("Tick() did not time out player one after %v", 5*time.Second)
+	}
+}
+
+func TestGame_Clock_StartsWithGame(t *testing.T) {
+	g, clock := newTimedGame(t, game.TimeControl{Base: time.Minute, PerMove: 10 * time.Second})
+
+	// Stalling before the first roll counts.
+	clock.advance(time.Hour)
+	if _, err := g.Roll("playerOne"); !errors.Is(err, game.ErrOutOfTime) {
+		t.Errorf("Roll() error = %v, want %v", err, game.ErrOutOfTime)
+	}
+	if want := []time.Duration{0, time.Minute}; !reflect.DeepEqual(g.Remaining, want) {
+		t.Errorf("Remaining got %v, want %v", g.Remaining, want)
+	}
+}
+
+func TestGame_Clock_UndoRedo(t *testing.T) {
+	g, clock := newTimedGame(t, game.TimeControl{Base: time.Minute})
+
+	clock.advance(10 * time.Second)
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	clock.advance(40 * time.Second)
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if want := []time.Duration{10 * time.Second, time.Minute}; !reflect.DeepEqual(g.Remaining, want) {
+		t.Fatalf("Remaining got = %v, want %v", g.Remaining, want)
+	}
+
+	// Taking the placement back gives player one the time for it back, and their clock starts over.
+	clock.advance(time.Second)
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if want := []time.Duration{time.Minute, time.Minute}; !reflect.DeepEqual(g.Remaining, want) {
+		t.Errorf("Undo() got remaining %v, want %v", g.Remaining, want)
+	}
+	clock.advance(5 * time.Second)
+	if left, _ := g.TimeLeft(); left != 55*time.Second {
+		t.Errorf("TimeLeft() after Undo() got = %v, want %v", left, 55*time.Second)
+	}
+
+	// Playing it again doesn't charge anyone, and the clock of player two starts now.
+	if err := g.Redo(); err != nil {
+		t.Fatalf("Redo() error = %v", err)
+	}
+	if want := []time.Duration{time.Minute, time.Minute}; !reflect.DeepEqual(g.Remaining, want) {
+		t.Errorf("Redo() got remaining %v, want %v", g.Remaining, want)
+	}
+	clock.advance(2 * time.Second)
+	if left, _ := g.TimeLeft(); g.CurrentPlayer().ID != "playerTwo" || left != 58*time.Second {
+		t.Errorf("TimeLeft() after Redo() got = %v for %s, want %v for playerTwo", left, g.CurrentPlayer().ID, 58*time.Second)
+	}
+}
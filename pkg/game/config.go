@@ -0,0 +1,65 @@
+package game
+
+import (
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// Config holds the rules a game is played with. The zero value is the standard game.
+type Config struct {
+	// PassLimit is the number of passes in a row that ends the game. Zero means one pass for every player, ie nobody
+	// could or would place their roll for a whole round.
+	PassLimit int
+	// Scorer keeps score during the game. Nil means AreaScorer.
+	Scorer Scorer
+	// Seed seeds the dice of the game. Zero means the dice are seeded from the time of the first roll.
+	Seed int64
+	// Ruleset decides where pieces can be placed. Nil means the standard Rules.
+	Ruleset Ruleset
+	// Map is the shape of the board. Nil means a plain rectangle.
+	Map *Map
+	// Dice are the faces of both dice, which also limit how large pieces can be. Nil means D6.
+	Dice Faces
+	// Bounds limit the size of the board. The zero value means DefaultBounds.
+	Bounds Bounds
+	// Doubles is the bonus for rolling a double. The zero value means there is none.
+	Doubles Doubles
+	// Fair rolls the dice with the commit-reveal scheme of Fairness. It can't be combined with a Seed.
+	Fair bool
+	// Time limits how long players can take. The zero value means there is no limit.
+	Time TimeControl
+	// Clock times the turns if there is a time control. Nil means the wall clock.
+	Clock Clock
+}
+
+// validate checks that the dice, the time control and the seed of the config go together.
+func (c Config) validate() error {
+	if c.Dice != nil {
+		if err := c.Dice.validate(); err != nil {
+			return err
+		}
+	}
+	if err := c.Time.validate(); err != nil {
+		return err
+	}
+	if c.Fair && c.Seed != 0 {
+		return errors.New("fair dice can't have a seed")
+	}
+	return nil
+}
+
+func (c Config) passLimit(players int) int {
+	if c.PassLimit > 0 {
+		return c.PassLimit
+	}
+	return players
+}
+
+func (c Config) dice() Dice {
+	seed := c.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	return Dice{source: rand.New(rand.NewSource(seed)), faces: c.Dice.orD6()}
+}
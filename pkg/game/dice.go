@@ -0,0 +1,119 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+)
+
+// Faces are the numbers on the sides of a die. Every side comes up with the same chance, so a number on more than one
+// side comes up more often.
+type Faces []uint8
+
+// The usual dice.
+var (
+	D4 = Faces{1, 2, 3, 4}
+	D6 = Faces{1, 2, 3, 4, 5, 6}
+	D8 = Faces{1, 2, 3, 4, 5, 6, 7, 8}
+)
+
+// Max returns the highest number on the die, which is the longest side a piece can have.
+func (f Faces) Max() uint8 {
+	var max uint8
+	for _, n := range f {
+		if n > max {
+			max = n
+		}
+	}
+	return max
+}
+
+// Has checks whether the number is on one of the sides of the die.
+func (f Faces) Has(n uint8) bool {
+	for _, face := range f {
+		if face == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (f Faces) validate() error {
+	if len(f) == 0 {
+		return errors.New("die has no faces")
+	}
+	if f.Has(0) {
+		return fmt.Errorf("die %v has a face with zero", f)
+	}
+	return nil
+}
+
+// orD6 returns the faces, or those of a six sided die if there are none.
+func (f Faces) orD6() Faces {
+	if len(f) == 0 {
+		return D6
+	}
+	return f
+}
+
+// Source is where dice get their randomness from. *rand.Rand satisfies it.
+type Source interface {
+	Intn(n int) int
+}
+
+// Roller rolls the dice for a turn.
+type Roller interface {
+	Roll() Roll
+}
+
+// Roll is the outcome of rolling two dice. The first die is the width, the second die is the height of the piece the
+// player has to place.
+type Roll struct {
+	Width  uint8
+	Height uint8
+}
+
+// Fits checks whether a piece with the given dimensions matches the roll, either as rolled or rotated by 90 degrees.
+func (r Roll) Fits(width uint8, height uint8) bool {
+	return r.Width == width && r.Height == height || r.Width == height && r.Height == width
+}
+
+// Dice is a pair of dice with the same faces, six sided unless they are made with other faces.
+type Dice struct {
+	source Source
+	faces  Faces
+}
+
+// NewDice returns a pair of six sided dice that draw from the given source.
+func NewDice(source Source) (Dice, error) {
+	return NewDiceWithFaces(source, D6)
+}
+
+// NewDiceWithFaces returns a pair of dice with the given faces that draw from the source.
+func NewDiceWithFaces(source Source, faces Faces) (Dice, error) {
+	if source == nil {
+		return Dice{}, errors.New("can't create dice without a source")
+	}
+	if err := faces.validate(); err != nil {
+		return Dice{}, fmt.Errorf("game.NewDiceWithFaces(): %w", err)
+	}
+	return Dice{source: source, faces: append(Faces(nil), faces...)}, nil
+}
+
+// NewSeededDice returns a pair of six sided dice whose rolls are fully determined by the seed.
+func NewSeededDice(seed int64) Dice {
+	return Dice{source: rand.New(rand.NewSource(seed)), faces: D6}
+}
+
+// Roll rolls both dice.
+func (d Dice) Roll() Roll {
+	return Roll{
+		Width:  d.face(),
+		Height: d.face(),
+	}
+}
+
+func (d Dice) face() uint8 {
+	faces := d.faces.orD6()
+	return faces[d.source.Intn(len(faces))]
+}
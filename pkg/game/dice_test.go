@@ -0,0 +1,243 @@
+package game_test
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// fixedSource returns the values it was given in order, looping around at the end.
+type fixedSource struct {
+	values []int
+	next   int
+}
+
+func (f *fixedSource) Intn(n int) int {
+	v := f.values[f.next%len(f.values)] % n
+	f.next++
+	return v
+}
+
+func TestNewDice(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  game.Source
+		wantErr bool
+	}{
+		{
+			name:    "can create dice with a source",
+			source:  rand.New(rand.NewSource(1)),
+			wantErr: false,
+		},
+		{
+			name:    "can not create dice without a source",
+			source:  nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := game.NewDice(tt.source)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDice_Roll(t *testing.T) {
+	tests := []struct {
+		name   string
+		source game.Source
+		want   []game.Roll
+	}{
+		{
+			name:   "first die is width, second die is height",
+			source: &fixedSource{values: []int{2, 4}},
+			want: []game.Roll{
+				{Width: 3, Height: 5},
+			},
+		},
+		{
+			name:   "faces go from 1 to 6",
+			source: &fixedSource{values: []int{0, 5, 5, 0}},
+			want: []game.Roll{
+				{Width: 1, Height: 6},
+				{Width: 6, Height: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := game.NewDice(tt.source)
+			if err != nil {
+				t.Fatalf("NewDice() error = %v", err)
+			}
+
+			var got []game.Roll
+			for range tt.want {
+				got = append(got, d.Roll())
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Roll() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSeededDice(t *testing.T) {
+	a := game.NewSeededDice(42)
+	b := game.NewSeededDice(42)
+
+	for i := 0; i < 100; i++ {
+		ra, rb := a.Roll(), b.Roll()
+		if ra != rb {
+			t.Fatalf("Roll() #%d differs for the same seed: %v and %v", i, ra, rb)
+		}
+		if ra.Width < 1 || ra.Width > 6 || ra.Height < 1 || ra.Height > 6 {
+			t.Fatalf("Roll() #%d out of range: %v", i, ra)
+		}
+	}
+}
+
+func TestRoll_Fits(t *testing.T) {
+	type args struct {
+		width  uint8
+		height uint8
+	}
+	tests := []struct {
+		name string
+		roll game.Roll
+		args args
+		want bool
+	}{
+		{
+			name: "same dimensions fit",
+			roll: game.Roll{Width: 2, Height: 5},
+			args: args{width: 2, height: 5},
+			want: true,
+		},
+		{
+			name: "rotated dimensions fit",
+			roll: game.Roll{Width: 2, Height: 5},
+			args: args{width: 5, height: 2},
+			want: true,
+		},
+		{
+			name: "different dimensions do not fit",
+			roll: game.Roll{Width: 2, Height: 5},
+			args: args{width: 3, height: 5},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.roll.Fits(tt.args.width, tt.args.height); got != tt.want {
+				t.Errorf("Fits() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDiceWithFaces(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  game.Source
+		faces   game.Faces
+		wantErr bool
+	}{
+		{
+			name:    "can create dice with faces",
+			source:  rand.New(rand.NewSource(1)),
+			faces:   game.D8,
+			wantErr: false,
+		},
+		{
+			name:    "can not create dice without faces",
+			source:  rand.New(rand.NewSource(1)),
+			faces:   game.Faces{},
+			wantErr: true,
+		},
+		{
+			name:    "can not create dice with a zero face",
+			source:  rand.New(rand.NewSource(1)),
+			faces:   game.Faces{0, 1, 2},
+			wantErr: true,
+		},
+		{
+			name:    "can not create dice without a source",
+			source:  nil,
+			faces:   game.D4,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := game.NewDiceWithFaces(tt.source, tt.faces)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewDiceWithFaces() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDice_Roll_Faces(t *testing.T) {
+	tests := []struct {
+		name   string
+		faces  game.Faces
+		source game.Source
+		want   []game.Roll
+	}{
+		{
+			name:   "d4",
+			faces:  game.D4,
+			source: &fixedSource{values: []int{0, 3, 3, 1}},
+			want:   []game.Roll{{Width: 1, Height: 4}, {Width: 4, Height: 2}},
+		},
+		{
+			name:   "d8",
+			faces:  game.D8,
+			source: &fixedSource{values: []int{7, 6}},
+			want:   []game.Roll{{Width: 8, Height: 7}},
+		},
+		{
+			name:   "custom faces",
+			faces:  game.Faces{1, 1, 2, 3, 4, 6},
+			source: &fixedSource{values: []int{0, 1, 4, 5}},
+			want:   []game.Roll{{Width: 1, Height: 1}, {Width: 4, Height: 6}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := game.NewDiceWithFaces(tt.source, tt.faces)
+			if err != nil {
+				t.Fatalf("NewDiceWithFaces() error = %v", err)
+			}
+
+			var got []game.Roll
+			for range tt.want {
+				got = append(got, d.Roll())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Roll() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFaces(t *testing.T) {
+	faces := game.Faces{1, 1, 2, 3, 4, 6}
+
+	if got := faces.Max(); got != 6 {
+		t.Errorf("Max() = %d, want 6", got)
+	}
+	if !faces.Has(6) || faces.Has(5) {
+		t.Errorf("Has() got 6 = %t, 5 = %t, want true, false", faces.Has(6), faces.Has(5))
+	}
+	if got := game.D8.Max(); got != 8 {
+		t.Errorf("D8.Max() = %d, want 8", got)
+	}
+}
@@ -0,0 +1,39 @@
+package game
+
+import "fmt"
+
+// Doubles is the bonus a player gets for rolling the same number on both dice.
+type Doubles uint8
+
+const (
+	// DoublesNoBonus means doubles are played like any other roll. This is the standard game.
+	DoublesNoBonus Doubles = iota
+	// DoublesExtraTurn gives the player another turn after placing a double.
+	DoublesExtraTurn
+	// DoublesAnywhere lets the square piece of a double go on any free cells of the board, whether or not it borders
+	// the player's own pieces or covers their starting corner. It still can't overlap other pieces or blocked cells.
+	DoublesAnywhere
+)
+
+func (d Doubles) String() string {
+	switch d {
+	case DoublesNoBonus:
+		return "no bonus"
+	case DoublesExtraTurn:
+		return "extra turn"
+	case DoublesAnywhere:
+		return "anywhere"
+	default:
+		return fmt.Sprintf("unknown doubles %d", uint8(d))
+	}
+}
+
+// IsDouble checks whether both dice show the same number, which makes a square piece.
+func (r Roll) IsDouble() bool {
+	return r.Width == r.Height
+}
+
+// anywhere checks whether the piece is a square the doubles rule of the board lets go on any free cells.
+func (b *Board) anywhere(p *Piece) bool {
+	return b.Doubles == DoublesAnywhere && p.Width == p.Height
+}
@@ -0,0 +1,335 @@
+package game_test
+
+import (
+	"errors"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// newDoublesGame returns a game with the doubles rule whose dice come up with the values given.
+func newDoublesGame(t *testing.T, doubles game.Doubles, width uint8, height uint8, values ...int) game.Game {
+	t.Helper()
+
+	config := game.Config{Doubles: doubles, Bounds: game.Bounds{MinWidth: 2, MaxWidth: 24, MinHeight: 2, MaxHeight: 30}}
+	g, err := game.NewWithConfig("1", config, width, height, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: values})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+	return g
+}
+
+func TestRoll_IsDouble(t *testing.T) {
+	tests := []struct {
+		name string
+		roll game.Roll
+		want bool
+	}{
+		{
+			name: "same number on both dice",
+			roll: game.Roll{Width: 3, Height: 3},
+			want: true,
+		},
+		{
+			name: "different numbers",
+			roll: game.Roll{Width: 3, Height: 4},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.roll.IsDouble(); got != tt.want {
+				t.Errorf("IsDouble() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGame_Doubles_ExtraTurn(t *testing.T) {
+	tests := []struct {
+		name        string
+		doubles     game.Doubles
+		width       uint8
+		height      uint8
+		values      []int
+		origin      game.Coordinate
+		place       bool
+		wantCurrent int
+		wantPasses  int
+	}{
+		{
+			name:        "placed double earns another turn",
+			doubles:     game.DoublesExtraTurn,
+			width:       8,
+			height:      12,
+			values:      []int{1, 1},
+			origin:      game.Coordinate{X: 1, Y: 1},
+			place:       true,
+			wantCurrent: 0,
+		},
+		{
+			name:        "other rolls end the turn",
+			doubles:     game.DoublesExtraTurn,
+			width:       8,
+			height:      12,
+			values:      []int{1, 2},
+			origin:      game.Coordinate{X: 1, Y: 1},
+			place:       true,
+			wantCurrent: 1,
+		},
+		{
+			name:        "doubles end the turn without the rule",
+			doubles:     game.DoublesNoBonus,
+			width:       8,
+			height:      12,
+			values:      []int{1, 1},
+			origin:      game.Coordinate{X: 1, Y: 1},
+			place:       true,
+			wantCurrent: 1,
+		},
+		{
+			name:        "double passed by the player earns nothing",
+			doubles:     game.DoublesExtraTurn,
+			width:       8,
+			height:      12,
+			values:      []int{1, 1},
+			wantCurrent: 1,
+			wantPasses:  1,
+		},
+		{
+			name:        "double too large for the board is passed and earns nothing",
+			doubles:     game.DoublesExtraTurn,
+			width:       4,
+			height:      4,
+			values:      []int{4, 4},
+			wantCurrent: 1,
+			wantPasses:  1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newDoublesGame(t, tt.doubles, tt.width, tt.height, tt.values...)
+
+			if _, err := g.Roll("playerOne"); err != nil {
+				t.Fatalf("Roll() error = %v", err)
+			}
+			switch {
+			case tt.place:
+				if err := g.Place("playerOne", tt.origin, false); err != nil {
+					t.Fatalf("Place() error = %v", err)
+				}
+			case g.Phase == game.AwaitingPlacement:
+				if err := g.Pass("playerOne"); err != nil {
+					t.Fatalf("Pass() error = %v", err)
+				}
+			}
+
+			if g.Current != tt.wantCurrent || g.Phase != game.AwaitingRoll || g.Passes != tt.wantPasses {
+				t.Errorf("got current = %d, phase = %v, passes = %d, want %d, %v, %d", g.Current, g.Phase, g.Passes, tt.wantCurrent, game.AwaitingRoll, tt.wantPasses)
+			}
+		})
+	}
+}
+
+func TestGame_Doubles_ExtraTurnUndo(t *testing.T) {
+	g := newDoublesGame(t, game.DoublesExtraTurn, 8, 12, 1, 1, 0, 1)
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() for the extra turn error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 3, Y: 1}, false); err != nil {
+		t.Fatalf("Place() for the extra turn error = %v", err)
+	}
+	if g.CurrentPlayer().ID != "playerTwo" {
+		t.Fatalf("extra turn did not end, current = %s", g.CurrentPlayer().ID)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := g.Undo(); err != nil {
+			t.Fatalf("Undo() error = %v", err)
+		}
+	}
+	if g.CurrentPlayer().ID != "playerOne" || g.Phase != game.AwaitingRoll || len(g.Board.Pieces) != 1 {
+		t.Errorf("Undo() got current = %s, phase = %v, pieces = %d", g.CurrentPlayer().ID, g.Phase, len(g.Board.Pieces))
+	}
+}
+
+func TestGame_Doubles_Anywhere(t *testing.T) {
+	tests := []struct {
+		name      string
+		doubles   game.Doubles
+		values    []int
+		origin    game.Coordinate
+		wantErr   bool
+		wantPhase game.Phase
+	}{
+		{
+			name:      "double goes anywhere",
+			doubles:   game.DoublesAnywhere,
+			values:    []int{2, 2},
+			origin:    game.Coordinate{X: 3, Y: 5},
+			wantPhase: game.AwaitingRoll,
+		},
+		{
+			name:      "double still borders own pieces without the rule",
+			doubles:   game.DoublesNoBonus,
+			values:    []int{2, 2},
+			origin:    game.Coordinate{X: 3, Y: 5},
+			wantErr:   true,
+			wantPhase: game.AwaitingPlacement,
+		},
+		{
+			name:      "double can't overlap",
+			doubles:   game.DoublesAnywhere,
+			values:    []int{2, 2},
+			origin:    game.Coordinate{X: 1, Y: 1},
+			wantErr:   true,
+			wantPhase: game.AwaitingPlacement,
+		},
+		{
+			name:      "other rolls still border own pieces",
+			doubles:   game.DoublesAnywhere,
+			values:    []int{1, 2},
+			origin:    game.Coordinate{X: 3, Y: 5},
+			wantErr:   true,
+			wantPhase: game.AwaitingPlacement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Both players open with a 1x2 piece in their corner.
+			g := newDoublesGame(t, tt.doubles, 8, 12, append([]int{0, 1, 0, 1}, tt.values...)...)
+			opening := []game.Coordinate{{X: 1, Y: 1}, {X: 8, Y: 11}}
+			for i, player := range []string{"playerOne", "playerTwo"} {
+				if _, err := g.Roll(player); err != nil {
+					t.Fatalf("Roll() error = %v", err)
+				}
+				if err := g.Place(player, opening[i], false); err != nil {
+					t.Fatalf("Place() error = %v", err)
+				}
+			}
+
+			if _, err := g.Roll("playerOne"); err != nil {
+				t.Fatalf("Roll() error = %v", err)
+			}
+			if err := g.Place("playerOne", tt.origin, false); (err != nil) != tt.wantErr {
+				t.Errorf("Place() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if g.Phase != tt.wantPhase {
+				t.Errorf("Place() phase = %v, want %v", g.Phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestGame_Doubles_AnywhereOpening(t *testing.T) {
+	g := newDoublesGame(t, game.DoublesAnywhere, 8, 12, 1, 1)
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 4, Y: 6}, false); err != nil {
+		t.Errorf("Place() of an opening double away from the corner error = %v", err)
+	}
+}
+
+func TestGame_Doubles_AnywhereImpossible(t *testing.T) {
+	// Player one covers the left half of the board, so there is free space left, but not for a 3x3 square.
+	g := newDoublesGame(t, game.DoublesAnywhere, 4, 4, 1, 3, 2, 2)
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+
+	if got := g.Board.LegalPlacements("playerTwo", 3, 3); len(got) != 0 {
+		t.Errorf("LegalPlacements() got = %v, want none", got)
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	last := g.History[len(g.History)-1]
+	if last.Kind != game.MovePass || !last.Forced || g.CurrentPlayer().ID != "playerOne" || g.Board.FreeCells() != 8 {
+		t.Errorf("Roll() got last move %v, current = %s, free cells = %d", last, g.CurrentPlayer().ID, g.Board.FreeCells())
+	}
+}
+
+func TestBoard_LegalPlacements_DoublesAnywhere(t *testing.T) {
+	g := newDoublesGame(t, game.DoublesAnywhere, 8, 12, 0, 1)
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+
+	// Every 2x2 spot that doesn't cover the piece in A1:A2: 7x11 spots, minus the 2 that overlap it.
+	if got := len(g.Board.LegalPlacements("playerOne", 2, 2)); got != 75 {
+		t.Errorf("LegalPlacements() got %d placements, want %d", got, 75)
+	}
+	// Other sizes keep to the ruleset: three spots along the piece in each orientation.
+	if got := len(g.Board.LegalPlacements("playerOne", 1, 2)); got != 6 {
+		t.Errorf("LegalPlacements() got %d placements for 1x2, want %d", got, 6)
+	}
+}
+
+func TestBoard_PlacePiece_DoublesAnywhereProtectTerritory(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     game.Rules
+		piece     game.Piece
+		wantErr   error
+		wantLegal int
+	}{
+		{
+			name:      "square anywhere outside territory",
+			rules:     game.Rules{ProtectTerritory: true},
+			piece:     mustNewPiece(t, "playerTwo", 5, 5, 1, 1),
+			wantLegal: 86,
+		},
+		{
+			name:      "square can't go into protected territory",
+			rules:     game.Rules{ProtectTerritory: true},
+			piece:     mustNewPiece(t, "playerTwo", 2, 2, 1, 1),
+			wantErr:   game.ErrNotAllowed,
+			wantLegal: 86,
+		},
+		{
+			name:      "square goes into territory nobody protects",
+			piece:     mustNewPiece(t, "playerTwo", 2, 2, 1, 1),
+			wantLegal: 88,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Doubles = game.DoublesAnywhere
+			b.Ruleset = tt.rules
+			b.Pieces = append(enclosingPieces(t), mustNewPiece(t, "playerTwo", 8, 12, 1, 1))
+
+			// Every free cell, but the two player one enclosed if they're protected.
+			if got := len(b.LegalPlacements("playerTwo", 1, 1)); got != tt.wantLegal {
+				t.Errorf("LegalPlacements() got %d placements, want %d", got, tt.wantLegal)
+			}
+			if _, err := b.PlacePiece(tt.piece); !errors.Is(err, tt.wantErr) {
+				t.Errorf("PlacePiece() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
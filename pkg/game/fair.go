@@ -0,0 +1,195 @@
+package game
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var (
+	// ErrNotFair is returned when using the fairness scheme on a game that doesn't have fair dice.
+	ErrNotFair = errors.New("game does not have fair dice")
+	// ErrMissingEntropy is returned when rolling fair dice before every player contributed their entropy.
+	ErrMissingEntropy = errors.New("not every player contributed entropy")
+	// ErrNotRevealed is returned when verifying the rolls of a game whose server seed is still secret.
+	ErrNotRevealed = errors.New("server seed not revealed")
+	// ErrUnfair is returned when the rolls of a game don't match what the committed seed says they are.
+	ErrUnfair = errors.New("rolls don't match the commitment")
+	// ErrRevealed is returned when taking back or playing again moves of a game with fair dice whose server seed was
+	// revealed. Anyone could work out the rolls to come from then on.
+	ErrRevealed = errors.New("server seed revealed")
+)
+
+// serverSeedSize is the number of random bytes in a server seed.
+const serverSeedSize = 32
+
+// Fairness is the commit-reveal scheme of a game with fair dice, so players don't have to trust the server with the
+// rolls. When the game starts, the server picks a secret seed and publishes its SHA-256 hash as the commitment. Every
+// player then contributes some entropy of their own before the first roll, so the server can't pick a seed that suits
+// anyone either. The rolls follow from the seed and the entropy, and once the game is over, the seed is revealed and
+// anyone can check every roll against the history of the game with Game.VerifyRolls.
+//
+// The combined seed is the SHA-256 hash of the server seed followed by the entropy of every player in turn order,
+// each prefixed with its length as a big endian uint32. Roll n of the game, counting from 1, rolls die 0 for the width
+// and die 1 for the height. A die takes the first 8 bytes of the SHA-256 hash of the combined seed, n as a big endian
+// uint64, the number of the die as a byte and a counter as a big endian uint32 starting at 0, as a big endian uint64.
+// Values past the largest multiple of the number of faces are thrown away and the counter is increased, any other
+// value modulo the number of faces is the index of the face that came up.
+type Fairness struct {
+	// Commitment is the SHA-256 hash of the server seed.
+	Commitment []byte
+	// Entropy is what every player contributed, in the order of Board.Players. Nil for the players who haven't yet.
+	Entropy [][]byte
+	// Seed is the server seed. It has to stay secret until the game is over, MarshalJSON and WriteRecord leave it
+	// out until then. Servers store games that are still going with Game.MarshalPrivateJSON, which keeps it.
+	Seed []byte
+}
+
+// NewFairness returns the fairness scheme for a game with the server seed, committing to it.
+func NewFairness(seed []byte) *Fairness {
+	commitment := sha256.Sum256(seed)
+	return &Fairness{Commitment: commitment[:], Seed: append([]byte(nil), seed...)}
+}
+
+// newRandomFairness returns the fairness scheme for a game with a random server seed.
+func newRandomFairness() (*Fairness, error) {
+	seed := make([]byte, serverSeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("game: can't pick a server seed: %w", err)
+	}
+	return NewFairness(seed), nil
+}
+
+// Contribute adds the entropy of the player to the dice of a game with fair dice. Every player contributes once, before
+// the first roll of the game.
+func (g *Game) Contribute(playerID string, entropy []byte) error {
+	f := g.Fairness
+	if f == nil {
+		return ErrNotFair
+	}
+	if len(entropy) == 0 {
+		return fmt.Errorf("game.Contribute(): %q contributed no entropy", playerID)
+	}
+	if g.rolls() > 0 {
+		return &TurnError{Action: "contribute", Player: playerID, Phase: g.Phase, Err: ErrWrongPhase}
+	}
+
+	for i, p := range g.Board.Players {
+		if p.ID != playerID {
+			continue
+		}
+		if f.Entropy == nil {
+			f.Entropy = make([][]byte, len(g.Board.Players))
+		}
+		if f.Entropy[i] != nil {
+			return fmt.Errorf("game.Contribute(): %q contributed already", playerID)
+		}
+		f.Entropy[i] = append([]byte(nil), entropy...)
+		return nil
+	}
+
+	return fmt.Errorf("game.Contribute(): %q is not playing", playerID)
+}
+
+// VerifyRolls checks every roll in the history of a game with fair dice against the revealed server seed and the
+// entropy of the players.
+func (g *Game) VerifyRolls() error {
+	f := g.Fairness
+	if f == nil {
+		return ErrNotFair
+	}
+	if f.Seed == nil {
+		return ErrNotRevealed
+	}
+	if commitment := sha256.Sum256(f.Seed); !bytes.Equal(commitment[:], f.Commitment) {
+		return fmt.Errorf("%w: server seed does not hash to the commitment", ErrUnfair)
+	}
+
+	n := 0
+	for i, m := range g.History {
+		if m.Kind != MoveRoll {
+			continue
+		}
+		n++
+		want, err := f.roll(g.Board.dice(), n, len(g.Board.Players))
+		if err != nil {
+			return fmt.Errorf("game.VerifyRolls(): %w", err)
+		}
+		if m.Roll != want {
+			return fmt.Errorf("%w: move %d rolled %dx%d, but roll %d is %dx%d", ErrUnfair, i+1, m.Roll.Width, m.Roll.Height, n, want.Width, want.Height)
+		}
+	}
+
+	return nil
+}
+
+// revealed checks whether the server seed can be shown to the players.
+func (g *Game) revealed() bool {
+	return g.Phase == GameOver
+}
+
+// rolls returns the number of rolls in the history of the game.
+func (g *Game) rolls() int {
+	n := 0
+	for _, m := range g.History {
+		if m.Kind == MoveRoll {
+			n++
+		}
+	}
+	return n
+}
+
+// roll returns roll n of the game, counting from 1. Rolling again after taking a roll back with Undo comes up the
+// same.
+func (f *Fairness) roll(faces Faces, n int, players int) (Roll, error) {
+	if f.Seed == nil {
+		return Roll{}, errors.New("fair dice have no server seed")
+	}
+	if len(f.Entropy) != players {
+		return Roll{}, ErrMissingEntropy
+	}
+	for _, e := range f.Entropy {
+		if e == nil {
+			return Roll{}, ErrMissingEntropy
+		}
+	}
+
+	combined := f.combined()
+	return Roll{Width: fairFace(combined, faces, n, 0), Height: fairFace(combined, faces, n, 1)}, nil
+}
+
+// combined returns the hash of the server seed and the entropy of all players.
+func (f *Fairness) combined() []byte {
+	h := sha256.New()
+	h.Write(f.Seed)
+	for _, e := range f.Entropy {
+		var size [4]byte
+		binary.BigEndian.PutUint32(size[:], uint32(len(e)))
+		h.Write(size[:])
+		h.Write(e)
+	}
+	return h.Sum(nil)
+}
+
+// fairFace returns the face that came up on the die of roll n.
+func fairFace(combined []byte, faces Faces, n int, die byte) uint8 {
+	count := uint64(len(faces))
+	limit := math.MaxUint64 / count * count
+
+	// The combined seed, n, the die and the counter.
+	msg := make([]byte, len(combined)+8+1+4)
+	at := copy(msg, combined)
+	binary.BigEndian.PutUint64(msg[at:], uint64(n))
+	msg[at+8] = die
+	for counter := uint32(0); ; counter++ {
+		binary.BigEndian.PutUint32(msg[at+9:], counter)
+		sum := sha256.Sum256(msg)
+		if v := binary.BigEndian.Uint64(sum[:8]); v < limit {
+			return faces[v%count]
+		}
+	}
+}
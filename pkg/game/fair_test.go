@@ -0,0 +1,418 @@
+package game_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+var serverSeed = []byte("server seed for the tests")
+
+// newFairGame returns a game with fair dice from a known server seed, and the entropy of both players in.
+func newFairGame(t *testing.T, config game.Config) game.Game {
+	t.Helper()
+
+	config.Fair = true
+	g, err := game.NewWithConfig("1", config, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	g.Fairness = game.NewFairness(serverSeed)
+
+	for _, id := range []string{"playerOne", "playerTwo"} {
+		if err := g.Contribute(id, []byte("entropy of "+id)); err != nil {
+			t.Fatalf("Contribute() error = %v", err)
+		}
+	}
+	return g
+}
+
+// finishFairGame has both players roll and pass, which ends the game.
+func finishFairGame(t *testing.T, g *game.Game) {
+	t.Helper()
+
+	for _, id := range []string{"playerOne", "playerTwo"} {
+		if _, err := g.Roll(id); err != nil {
+			t.Fatalf("Roll() error = %v", err)
+		}
+		if err := g.Pass(id); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+	}
+	if g.Phase != game.GameOver {
+		t.Fatalf("game is not over, phase %v", g.Phase)
+	}
+}
+
+// fairRoll derives roll n the way the documentation of Fairness describes it.
+func fairRoll(seed []byte, entropy [][]byte, faces game.Faces, n uint64) game.Roll {
+	h := sha256.New()
+	h.Write(seed)
+	for _, e := range entropy {
+		_ = binary.Write(h, binary.BigEndian, uint32(len(e)))
+		h.Write(e)
+	}
+	combined := h.Sum(nil)
+
+	die := func(d byte) uint8 {
+		// The largest multiple of the number of faces a uint64 can hold.
+		limit := uint64(math.MaxUint64) / uint64(len(faces)) * uint64(len(faces))
+		for counter := uint32(0); ; counter++ {
+			var buf bytes.Buffer
+			buf.Write(combined)
+			_ = binary.Write(&buf, binary.BigEndian, n)
+			buf.WriteByte(d)
+			_ = binary.Write(&buf, binary.BigEndian, counter)
+			sum := sha256.Sum256(buf.Bytes())
+			if v := binary.BigEndian.Uint64(sum[:8]); v < limit {
+				return faces[v%uint64(len(faces))]
+			}
+		}
+	}
+
+	return game.Roll{Width: die(0), Height: die(1)}
+}
+
+func TestNewWithConfig_Fair(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Fair: true}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	if g.Fairness == nil || len(g.Fairness.Seed) == 0 {
+		t.Fatalf("NewWithConfig() got fairness %v", g.Fairness)
+	}
+	if sum := sha256.Sum256(g.Fairness.Seed); !bytes.Equal(sum[:], g.Fairness.Commitment) {
+		t.Errorf("NewWithConfig() commitment %x is not the hash of the seed", g.Fairness.Commitment)
+	}
+
+	if _, err := game.NewWithConfig("1", game.Config{Fair: true, Seed: 1}, 12, 16, "playerOne", "playerTwo"); err == nil {
+		t.Errorf("NewWithConfig() of fair dice with a seed did not fail")
+	}
+}
+
+func TestGame_Contribute(t *testing.T) {
+	tests := []struct {
+		name    string
+		fair    bool
+		setup   func(t *testing.T, g *game.Game)
+		player  string
+		entropy []byte
+		wantErr bool
+		wantIs  error
+	}{
+		{
+			name:    "player contributes",
+			fair:    true,
+			setup:   func(t *testing.T, g *game.Game) {},
+			player:  "playerOne",
+			entropy: []byte("abc"),
+		},
+		{
+			name:    "game without fair dice",
+			fair:    false,
+			setup:   func(t *testing.T, g *game.Game) {},
+			player:  "playerOne",
+			entropy: []byte("abc"),
+			wantErr: true,
+			wantIs:  game.ErrNotFair,
+		},
+		{
+			name:    "no entropy",
+			fair:    true,
+			setup:   func(t *testing.T, g *game.Game) {},
+			player:  "playerOne",
+			wantErr: true,
+		},
+		{
+			name: "player contributes twice",
+			fair: true,
+			setup: func(t *testing.T, g *game.Game) {
+				if err := g.Contribute("playerOne", []byte("abc")); err != nil {
+					t.Fatalf("Contribute() error = %v", err)
+				}
+			},
+			player:  "playerOne",
+			entropy: []byte("def"),
+			wantErr: true,
+		},
+		{
+			name:    "player not in the game",
+			fair:    true,
+			setup:   func(t *testing.T, g *game.Game) {},
+			player:  "playerThree",
+			entropy: []byte("abc"),
+			wantErr: true,
+		},
+		{
+			name: "after the first roll",
+			fair: true,
+			setup: func(t *testing.T, g *game.Game) {
+				_ = g.Contribute("playerOne", []byte("abc"))
+				_ = g.Contribute("playerTwo", []byte("def"))
+				if _, err := g.Roll("playerOne"); err != nil {
+					t.Fatalf("Roll() error = %v", err)
+				}
+				if err := g.Pass("playerOne"); err != nil {
+					t.Fatalf("Pass() error = %v", err)
+				}
+				if err := g.Undo(); err != nil {
+					t.Fatalf("Undo() error = %v", err)
+				}
+			},
+			player:  "playerOne",
+			entropy: []byte("ghi"),
+			wantErr: true,
+			wantIs:  game.ErrWrongPhase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.NewWithConfig("1", game.Config{Fair: tt.fair}, 12, 16, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewWithConfig() error = %v", err)
+			}
+			tt.setup(t, &g)
+
+			err = g.Contribute(tt.player, tt.entropy)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Contribute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantIs != nil && !errors.Is(err, tt.wantIs) {
+				t.Errorf("Contribute() error = %v, want %v", err, tt.wantIs)
+			}
+		})
+	}
+}
+
+func TestGame_Roll_Fair(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Fair: true, PassLimit: 10}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	g.Fairness = game.NewFairness(serverSeed)
+	if err := g.Contribute("playerOne", []byte("entropy of playerOne")); err != nil {
+		t.Fatalf("Contribute() error = %v", err)
+	}
+
+	if _, err := g.Roll("playerOne"); !errors.Is(err, game.ErrMissingEntropy) {
+		t.Fatalf("Roll() before everyone contributed error = %v, want %v", err, game.ErrMissingEntropy)
+	}
+	if err := g.Contribute("playerTwo", []byte("entropy of playerTwo")); err != nil {
+		t.Fatalf("Contribute() error = %v", err)
+	}
+
+	entropy := [][]byte{[]byte("entropy of playerOne"), []byte("entropy of playerTwo")}
+	for n, id := range []string{"playerOne", "playerTwo", "playerOne"} {
+		got, err := g.Roll(id)
+		if err != nil {
+			t.Fatalf("Roll() error = %v", err)
+		}
+		if want := fairRoll(serverSeed, entropy, game.D6, uint64(n+1)); got != want {
+			t.Errorf("Roll() %d got = %v, want %v", n+1, got, want)
+		}
+		if g.Phase == game.AwaitingPlacement {
+			if err := g.Pass(id); err != nil {
+				t.Fatalf("Pass() error = %v", err)
+			}
+		}
+	}
+
+	// Taking a roll back and rolling again comes up the same.
+	want := g.History[len(g.History)-2].Roll
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if got, err := g.Roll("playerOne"); err != nil || got != want {
+		t.Errorf("Roll() after Undo() got = %v, %v, want %v", got, err, want)
+	}
+}
+
+func TestGame_UndoRedo_FairRevealed(t *testing.T) {
+	g := newFairGame(t, game.Config{})
+	finishFairGame(t, &g)
+	history := len(g.History)
+
+	if err := g.Undo(); !errors.Is(err, game.ErrRevealed) {
+		t.Errorf("Undo() error = %v, want %v", err, game.ErrRevealed)
+	}
+	if err := g.Redo(); !errors.Is(err, game.ErrRevealed) {
+		t.Errorf("Redo() error = %v, want %v", err, game.ErrRevealed)
+	}
+	if g.Phase != game.GameOver || len(g.History) != history {
+		t.Errorf("the game got taken back to %v with %d moves, want %v with %d", g.Phase, len(g.History), game.GameOver, history)
+	}
+}
+
+func TestGame_Roll_FairFaces(t *testing.T) {
+	faces := game.Faces{1, 1, 2, 3, 4, 6}
+	g := newFairGame(t, game.Config{Dice: faces})
+
+	got, err := g.Roll("playerOne")
+	if err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	entropy := [][]byte{[]byte("entropy of playerOne"), []byte("entropy of playerTwo")}
+	if want := fairRoll(serverSeed, entropy, faces, 1); got != want {
+		t.Errorf("Roll() got = %v, want %v", got, want)
+	}
+}
+
+func TestGame_VerifyRolls(t *testing.T) {
+	tests := []struct {
+		name    string
+		fair    bool
+		finish  bool
+		tamper  func(g *game.Game)
+		wantErr error
+	}{
+		{
+			name:   "honest rolls",
+			fair:   true,
+			finish: true,
+			tamper: func(g *game.Game) {},
+		},
+		{
+			name:    "game without fair dice",
+			finish:  true,
+			tamper:  func(g *game.Game) {},
+			wantErr: game.ErrNotFair,
+		},
+		{
+			name:    "game still going",
+			fair:    true,
+			tamper:  func(g *game.Game) {},
+			wantErr: game.ErrNotRevealed,
+		},
+		{
+			name:   "roll that was not rolled",
+			fair:   true,
+			finish: true,
+			tamper: func(g *game.Game) {
+				g.History[0].Roll.Width = g.History[0].Roll.Width%6 + 1
+			},
+			wantErr: game.ErrUnfair,
+		},
+		{
+			name:   "server seed that was not committed to",
+			fair:   true,
+			finish: true,
+			tamper: func(g *game.Game) {
+				g.Fairness.Seed = []byte("another seed")
+			},
+			wantErr: game.ErrUnfair,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.New("1", 12, 16, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if tt.fair {
+				g = newFairGame(t, game.Config{})
+			}
+			if tt.finish {
+				finishFairGame(t, &g)
+			} else if _, err := g.Roll("playerOne"); err != nil {
+				t.Fatalf("Roll() error = %v", err)
+			}
+
+			// Players only get to see the game as it's sent to them.
+			data, err := json.Marshal(g)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var got game.Game
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			tt.tamper(&got)
+
+			if err := got.VerifyRolls(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyRolls() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGame_MarshalJSON_FairSeedSecret(t *testing.T) {
+	g := newFairGame(t, game.Config{})
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if bytes.Contains(data, []byte(`"seed"`)) {
+		t.Errorf("Marshal() revealed the server seed of a game in progress: %s", data)
+	}
+
+	var got game.Game
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !bytes.Equal(got.Fairness.Commitment, g.Fairness.Commitment) || got.Fairness.Seed != nil || len(got.Fairness.Entropy) != 2 {
+		t.Errorf("Unmarshal() got fairness %+v", got.Fairness)
+	}
+}
+
+func TestGame_MarshalPrivateJSON_Fair(t *testing.T) {
+	g := newFairGame(t, game.Config{})
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Pass("playerOne"); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	// The server stores the game that is still going, and picks it up again.
+	data, err := g.MarshalPrivateJSON()
+	if err != nil {
+		t.Fatalf("MarshalPrivateJSON() error = %v", err)
+	}
+	var got game.Game
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !bytes.Equal(got.Fairness.Seed, serverSeed) {
+		t.Errorf("Unmarshal() got server seed %q, want %q", got.Fairness.Seed, serverSeed)
+	}
+
+	want, err := g.Roll("playerTwo")
+	if err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if roll, err := got.Roll("playerTwo"); err != nil || roll != want {
+		t.Errorf("Roll() of the stored game got %v, %v, want %v", roll, err, want)
+	}
+}
+
+func TestReadRecord_Fair(t *testing.T) {
+	g := newFairGame(t, game.Config{})
+	finishFairGame(t, &g)
+
+	var buf bytes.Buffer
+	if err := game.WriteRecord(&buf, &g); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+	got, err := game.ReadRecord(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecord() error = %v", err)
+	}
+
+	assertSameState(t, got, g)
+	if err := got.VerifyRolls(); err != nil {
+		t.Errorf("VerifyRolls() error = %v", err)
+	}
+}
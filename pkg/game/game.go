@@ -1,16 +1,280 @@
 package game
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Phase is the stage the current turn is in.
+type Phase uint8
+
+const (
+	// AwaitingRoll means the current player has to roll the dice.
+	AwaitingRoll Phase = iota
+	// AwaitingPlacement means the current player has rolled and has to place the piece or pass.
+	AwaitingPlacement
+	// GameOver means no more moves can be made.
+	GameOver
+)
+
+func (p Phase) String() string {
+	switch p {
+	case AwaitingRoll:
+		return "awaiting roll"
+	case AwaitingPlacement:
+		return "awaiting placement"
+	case GameOver:
+		return "game over"
+	default:
+		return fmt.Sprintf("unknown phase %d", uint8(p))
+	}
+}
+
+var (
+	// ErrNotYourTurn is returned when a player tries to act while it's someone else's turn.
+	ErrNotYourTurn = errors.New("not your turn")
+	// ErrWrongPhase is returned when an action is not allowed in the current phase of the turn.
+	ErrWrongPhase = errors.New("not allowed in this phase")
+)
+
+// TurnError describes an action that was refused by the turn engine.
+type TurnError struct {
+	Action string
+	Player string
+	Phase  Phase
+	Err    error
+}
+
+func (e *TurnError) Error() string {
+	return fmt.Sprintf("game: %s by %q while %s: %v", e.Action, e.Player, e.Phase, e.Err)
+}
+
+func (e *TurnError) Unwrap() error {
+	return e.Err
+}
 
 type Game struct {
 	Session string
 	Board   Board
+	Phase   Phase
+	// Current is the index of the player in Board.Players whose turn it is.
+	Current int
+	// Dice rolls for every player. Seeded from Config.Seed on the first roll if not set. Not used with fair dice.
+	Dice   Roller
+	Config Config
+	// Passes is the number of passes in a row since the last placed piece.
+	Passes int
+	// Ended is why the game is over, NotEnded while it's still going.
+	Ended EndReason
+	// History holds every move made so far, oldest first.
+	History []Move
+	// Fairness rolls the dice instead of Dice if the config asks for fair dice, nil otherwise.
+	Fairness *Fairness
+	// Remaining is the time each player has left for the rest of the game, in the order of Board.Players, not counting
+	// the turn in progress. Nil if the time control has no base time.
+	Remaining []time.Duration
+
+	// undo holds the state of the game before each move in History.
+	undo []turnState
+	// redo holds the moves that were taken back, the most recently taken back last.
+	redo []Move
+	// rolled holds what the dice came up with so far, oldest first, so a roll taken back comes up the same again.
+	rolled []Roll
+	// turnStarted is when the clock of the turn in progress started, zero if the game has no time control.
+	turnStarted time.Time
+}
+
+func New(session string, boardWidth uint8, boardHeight uint8, players ...string) (Game, error) {
+	return NewWithConfig(session, Config{}, boardWidth, boardHeight, players...)
+}
+
+// NewWithConfig returns a new game for 2 to 4 players played with the rules in the config. Players take turns in the
+// order they are given. If the config has a map, the board is the size of the map: the width and height have to match
+// it, or be zero.
+func NewWithConfig(session string, config Config, boardWidth uint8, boardHeight uint8, players ...string) (Game, error) {
+	if err := config.validate(); err != nil {
+		return Game{}, fmt.Errorf("game.NewWithConfig(): %w", err)
+	}
+
+	bounds := config.Bounds.orDefault()
+	var board Board
+	var err error
+	if m := config.Map; m != nil {
+		if (boardWidth != 0 || boardHeight != 0) && (boardWidth != m.Width || boardHeight != m.Height) {
+			return Game{}, fmt.Errorf("game.NewWithConfig(): board is %dx%d, but map %q is %dx%d", boardWidth, boardHeight, m.Name, m.Width, m.Height)
+		}
+		if board, err = bounds.NewMapBoard(m, players...); err != nil {
+			return Game{}, fmt.Errorf("game.NewWithConfig(): NewMapBoard(): %w", err)
+		}
+	} else if board, err = bounds.NewBoard(boardWidth, boardHeight, players...); err != nil {
+		return Game{}, fmt.Errorf("game.NewWithConfig(): NewBoard(): %w", err)
+	}
+	board.Scorer = config.Scorer
+	board.Ruleset = config.Ruleset
+	board.Dice = config.Dice
+	board.Doubles = config.Doubles
+
+	g := Game{Session: session, Board: board, Config: config}
+	if config.Fair {
+		if g.Fairness, err = newRandomFairness(); err != nil {
+			return Game{}, fmt.Errorf("game.NewWithConfig(): %w", err)
+		}
+	}
+	if config.Time.Base > 0 {
+		g.Remaining = make([]time.Duration, len(board.Players))
+		for i := range g.Remaining {
+			g.Remaining[i] = config.Time.Base
+		}
+	}
+	g.restartClock()
+	return g, nil
+}
+
+// CurrentPlayer returns the player whose turn it is.
+func (g *Game) CurrentPlayer() Player {
+	return g.Board.Players[g.Current]
+}
+
+// Roll rolls the dice for the current player. The roll is what they have to place next. If the piece can't be placed
+// anywhere, the player passes automatically.
+func (g *Game) Roll(playerID string) (Roll, error) {
+	var roll Roll
+	err := g.clocked("roll", playerID, func() error {
+		var err error
+		roll, err = g.rollDice(playerID)
+		return err
+	})
+	return roll, err
+}
+
+func (g *Game) rollDice(playerID string) (Roll, error) {
+	if err := g.checkTurn("roll", playerID, AwaitingRoll); err != nil {
+		return Roll{}, err
+	}
+
+	var roll Roll
+	if g.Fairness != nil {
+		var err error
+		if roll, err = g.Fairness.roll(g.Board.dice(), g.rolls()+1, len(g.Board.Players)); err != nil {
+			return Roll{}, fmt.Errorf("game.Roll(): %w", err)
+		}
+	} else {
+		roll = g.nextRoll()
+	}
+	if err := g.play(Move{Kind: MoveRoll, Player: playerID, Roll: roll}); err != nil {
+		return Roll{}, err
+	}
+
+	if len(g.Board.LegalPlacements(playerID, roll.Width, roll.Height)) == 0 {
+		if err := g.play(Move{Kind: MovePass, Player: playerID, Forced: true}); err != nil {
+			return Roll{}, err
+		}
+	}
+
+	return roll, nil
 }
 
-func New(session string, boardWidth uint8, boardHeight uint8, playerOne string, playerTwo string) (Game, error) {
-	board, err := NewBoard(boardWidth, boardHeight, playerOne, playerTwo)
+// Place places the rolled piece for the current player with its top left corner at origin, and ends their turn, unless
+// it was a double that earns them another one. If rotated is set, the piece is turned by 90 degrees, so a 2x5 roll is
+// placed as 5x2.
+func (g *Game) Place(playerID string, origin Coordinate, rotated bool) error {
+	return g.clocked("place", playerID, func() error {
+		return g.play(Move{Kind: MovePlace, Player: playerID, Origin: origin, Rotated: rotated})
+	})
+}
+
+// Pass gives up placing the rolled piece and ends the current player's turn.
+func (g *Game) Pass(playerID string) error {
+	return g.clocked("pass", playerID, func() error {
+		return g.play(Move{Kind: MovePass, Player: playerID})
+	})
+}
+
+func (g *Game) roll(playerID string, roll Roll) error {
+	if err := g.checkTurn("roll", playerID, AwaitingRoll); err != nil {
+		return err
+	}
+
+	g.Board.Roll = &roll
+	g.Phase = AwaitingPlacement
+	return nil
+}
+
+func (g *Game) place(playerID string, origin Coordinate, rotated bool) error {
+	if err := g.checkTurn("place", playerID, AwaitingPlacement); err != nil {
+		return err
+	}
+
+	p, err := g.Board.dice().NewPiece(playerID, origin.X, origin.Y, g.Board.Roll.Width, g.Board.Roll.Height)
 	if err != nil {
-		return Game{}, fmt.Errorf("game.New(): NewBoard(): %w", err)
+		return fmt.Errorf("game.Place(): NewPiece(): %w", err)
+	}
+
+	if rotated {
+		p = p.Rotated()
+	}
+	double := g.Board.Roll.IsDouble()
+
+	if _, err := g.Board.PlacePiece(p); err != nil {
+		return fmt.Errorf("game.Place(): PlacePiece(): %w", err)
+	}
+
+	g.Passes = 0
+	if g.Board.FreeCells() == 0 {
+		g.end(EndBoardFull)
+		return nil
+	}
+
+	// A placed double earns the player another turn if the rules say so.
+	if double && g.Config.Doubles == DoublesExtraTurn {
+		g.Phase = AwaitingRoll
+		return nil
 	}
-	return Game{Session: session, Board: board}, nil
+
+	g.endTurn()
+	return nil
+}
+
+func (g *Game) pass(playerID string) error {
+	if err := g.checkTurn("pass", playerID, AwaitingPlacement); err != nil {
+		return err
+	}
+
+	g.Board.Roll = nil
+	g.Passes++
+
+	if g.Passes >= g.Config.passLimit(g.playing()) {
+		g.end(EndPassLimit)
+		return nil
+	}
+
+	g.endTurn()
+	return nil
+}
+
+func (g *Game) checkTurn(action string, playerID string, phase Phase) error {
+	if g.Phase != phase {
+		return &TurnError{Action: action, Player: playerID, Phase: g.Phase, Err: ErrWrongPhase}
+	}
+	if g.CurrentPlayer().ID != playerID {
+		return &TurnError{Action: action, Player: playerID, Phase: g.Phase, Err: ErrNotYourTurn}
+	}
+	return nil
+}
+
+// endTurn passes the turn to the next player who is still in the game.
+func (g *Game) endTurn() {
+	for next := g.Current + 1; ; next++ {
+		if i := next % len(g.Board.Players); !g.forfeited(i) {
+			g.Current = i
+			break
+		}
+	}
+	g.Phase = AwaitingRoll
+}
+
+func (g *Game) end(reason EndReason) {
+	g.Phase = GameOver
+	g.Ended = reason
 }
@@ -1,6 +1,7 @@
 package game_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
@@ -128,3 +129,352 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+// newTestGame returns a game on a 12x16 board where both players already have a 1x1 piece in their corners, and the
+// dice roll the given faces in order.
+func newTestGame(t *testing.T, faces ...int) game.Game {
+	t.Helper()
+
+	g, err := game.New("1", 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i, c := range g.Board.Corners {
+		p, err := game.NewPiece(g.Board.Players[i].ID, c.X, c.Y, 1, 1)
+		if err != nil {
+			t.Fatalf("NewPiece() error = %v", err)
+		}
+		g.Board.Pieces = append(g.Board.Pieces, p)
+	}
+
+	values := make([]int, len(faces))
+	for i, f := range faces {
+		values[i] = f - 1
+	}
+	d, err := game.NewDice(&fixedSource{values: values})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	return g
+}
+
+func TestGame_Roll(t *testing.T) {
+	tests := []struct {
+		name      string
+		setup     func(g *game.Game)
+		player    string
+		want      game.Roll
+		wantPhase game.Phase
+		wantErr   error
+	}{
+		{
+			name:      "current player can roll",
+			setup:     func(g *game.Game) {},
+			player:    "playerOne",
+			want:      game.Roll{Width: 2, Height: 3},
+			wantPhase: game.AwaitingPlacement,
+		},
+		{
+			name:      "other player can not roll",
+			setup:     func(g *game.Game) {},
+			player:    "playerTwo",
+			wantPhase: game.AwaitingRoll,
+			wantErr:   game.ErrNotYourTurn,
+		},
+		{
+			name: "can not roll twice",
+			setup: func(g *game.Game) {
+				_, _ = g.Roll("playerOne")
+			},
+			player:    "playerOne",
+			wantPhase: game.AwaitingPlacement,
+			wantErr:   game.ErrWrongPhase,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, 2, 3)
+			tt.setup(&g)
+
+			got, err := g.Roll(tt.player)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Roll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Roll() got = %v, want %v", got, tt.want)
+			}
+			if g.Phase != tt.wantPhase {
+				t.Errorf("Roll() phase = %v, want %v", g.Phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestGame_Place(t *testing.T) {
+	tests := []struct {
+		name        string
+		roll        bool
+		player      string
+		origin      game.Coordinate
+		rotated     bool
+		wantPieces  int
+		wantCurrent int
+		wantPhase   game.Phase
+		wantErr     bool
+		wantErrIs   error
+	}{
+		{
+			name:        "current player can place the rolled piece",
+			roll:        true,
+			player:      "playerOne",
+			origin:      game.Coordinate{X: 2, Y: 1},
+			wantPieces:  3,
+			wantCurrent: 1,
+			wantPhase:   game.AwaitingRoll,
+		},
+		{
+			name:        "current player can place the rolled piece rotated",
+			roll:        true,
+			player:      "playerOne",
+			origin:      game.Coordinate{X: 1, Y: 2},
+			rotated:     true,
+			wantPieces:  3,
+			wantCurrent: 1,
+			wantPhase:   game.AwaitingRoll,
+		},
+		{
+			name:        "rotated piece still has to be legal",
+			roll:        true,
+			player:      "playerOne",
+			origin:      game.Coordinate{X: 1, Y: 10},
+			rotated:     true,
+			wantPieces:  2,
+			wantCurrent: 0,
+			wantPhase:   game.AwaitingPlacement,
+			wantErr:     true,
+		},
+		{
+			name:        "can not place before rolling",
+			roll:        false,
+			player:      "playerOne",
+			origin:      game.Coordinate{X: 2, Y: 1},
+			wantPieces:  2,
+			wantCurrent: 0,
+			wantPhase:   game.AwaitingRoll,
+			wantErr:     true,
+			wantErrIs:   game.ErrWrongPhase,
+		},
+		{
+			name:        "other player can not place",
+			roll:        true,
+			player:      "playerTwo",
+			origin:      game.Coordinate{X: 10, Y: 16},
+			wantPieces:  2,
+			wantCurrent: 0,
+			wantPhase:   game.AwaitingPlacement,
+			wantErr:     true,
+			wantErrIs:   game.ErrNotYourTurn,
+		},
+		{
+			name:        "illegal placement keeps the turn",
+			roll:        true,
+			player:      "playerOne",
+			origin:      game.Coordinate{X: 5, Y: 5},
+			wantPieces:  2,
+			wantCurrent: 0,
+			wantPhase:   game.AwaitingPlacement,
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, 2, 3)
+			if tt.roll {
+				if _, err := g.Roll("playerOne"); err != nil {
+					t.Fatalf("Roll() error = %v", err)
+				}
+			}
+
+			err := g.Place(tt.player, tt.origin, tt.rotated)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Place() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Place() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if len(g.Board.Pieces) != tt.wantPieces {
+				t.Fatalf("Place() pieces = %d, want %d", len(g.Board.Pieces), tt.wantPieces)
+			}
+			if last := g.Board.Pieces[len(g.Board.Pieces)-1]; tt.wantPieces > 2 && tt.rotated != (last.Width == 3) {
+				t.Errorf("Place() placed %dx%d piece, rotated %v", last.Width, last.Height, tt.rotated)
+			}
+			if g.Current != tt.wantCurrent {
+				t.Errorf("Place() current = %d, want %d", g.Current, tt.wantCurrent)
+			}
+			if g.Phase != tt.wantPhase {
+				t.Errorf("Place() phase = %v, want %v", g.Phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestGame_Pass(t *testing.T) {
+	g := newTestGame(t, 2, 3)
+
+	if err := g.Pass("playerOne"); !errors.Is(err, game.ErrWrongPhase) {
+		t.Errorf("Pass() before rolling error = %v, want %v", err, game.ErrWrongPhase)
+	}
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+
+	if err := g.Pass("playerTwo"); !errors.Is(err, game.ErrNotYourTurn) {
+		t.Errorf("Pass() by other player error = %v, want %v", err, game.ErrNotYourTurn)
+	}
+
+	if err := g.Pass("playerOne"); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	if g.CurrentPlayer().ID != "playerTwo" || g.Phase != game.AwaitingRoll || g.Board.Roll != nil {
+		t.Errorf("Pass() got current = %s, phase = %v, roll = %v", g.CurrentPlayer().ID, g.Phase, g.Board.Roll)
+	}
+}
+
+func TestGame_FourPlayers(t *testing.T) {
+	g, err := game.New("1", 12, 16, "playerOne", "playerTwo", "playerThree", "playerFour")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 0}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	// Every player opens in their own corner with a 2x1 piece, turned if needed to fit.
+	openings := []struct {
+		origin  game.Coordinate
+		rotated bool
+	}{
+		{origin: game.Coordinate{X: 1, Y: 1}},
+		{origin: game.Coordinate{X: 11, Y: 16}},
+		{origin: game.Coordinate{X: 12, Y: 1}, rotated: true},
+		{origin: game.Coordinate{X: 1, Y: 15}, rotated: true},
+	}
+	for i, o := range openings {
+		player := g.CurrentPlayer().ID
+		if player != g.Board.Players[i].ID {
+			t.Fatalf("CurrentPlayer() = %s, want %s", player, g.Board.Players[i].ID)
+		}
+		if _, err := g.Roll(player); err != nil {
+			t.Fatalf("Roll() error = %v", err)
+		}
+		if err := g.Place(player, o.origin, o.rotated); err != nil {
+			t.Fatalf("Place() by %s error = %v", player, err)
+		}
+	}
+
+	if g.CurrentPlayer().ID != "playerOne" {
+		t.Errorf("CurrentPlayer() after a round = %s, want playerOne", g.CurrentPlayer().ID)
+	}
+	for _, p := range g.Board.Players {
+		if p.Score != 2 {
+			t.Errorf("score of %s = %d, want 2", p.ID, p.Score)
+		}
+	}
+
+	// Three passes in a row are not enough to end a four player game.
+	for i := 0; i < 3; i++ {
+		player := g.CurrentPlayer().ID
+		if _, err := g.Roll(player); err != nil {
+			t.Fatalf("Roll() error = %v", err)
+		}
+		if err := g.Pass(player); err != nil {
+			t.Fatalf("Pass() error = %v", err)
+		}
+	}
+	if g.Phase == game.GameOver {
+		t.Errorf("game ended after 3 passes with 4 players")
+	}
+}
+
+func TestNewWithConfig_DiceAndBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  game.Config
+		width   uint8
+		height  uint8
+		wantMax uint8
+		wantErr bool
+	}{
+		{
+			name:    "quick game on a small board with a d4",
+			config:  game.Config{Seed: 3, Dice: game.D4, Bounds: game.Bounds{MinWidth: 4, MaxWidth: 6, MinHeight: 4, MaxHeight: 6}},
+			width:   5,
+			height:  5,
+			wantMax: 4,
+		},
+		{
+			name:    "marathon on a big board with a d8",
+			config:  game.Config{Seed: 3, Dice: game.D8, Bounds: game.Bounds{MinWidth: 8, MaxWidth: 100, MinHeight: 8, MaxHeight: 100}},
+			width:   60,
+			height:  80,
+			wantMax: 8,
+		},
+		{
+			name:    "small board with the default bounds",
+			config:  game.Config{Dice: game.D4},
+			width:   5,
+			height:  5,
+			wantErr: true,
+		},
+		{
+			name:    "dice with a zero face",
+			config:  game.Config{Dice: game.Faces{0, 1}},
+			width:   8,
+			height:  12,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.NewWithConfig("1", tt.config, tt.width, tt.height, "playerOne", "playerTwo")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewWithConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			// Play a few turns, every roll and piece stays within the dice.
+			for turn := 0; turn < 20 && g.Phase != game.GameOver; turn++ {
+				player := g.CurrentPlayer().ID
+				roll, err := g.Roll(player)
+				if err != nil {
+					t.Fatalf("Roll() error = %v", err)
+				}
+				if !tt.config.Dice.Has(roll.Width) || !tt.config.Dice.Has(roll.Height) {
+					t.Fatalf("Roll() = %v, not on the dice %v", roll, tt.config.Dice)
+				}
+				if g.Phase != game.AwaitingPlacement {
+					continue
+				}
+				pl := g.Board.LegalPlacements(player, roll.Width, roll.Height)[0]
+				if err := g.Place(player, pl.Origin, pl.Rotated); err != nil {
+					t.Fatalf("Place() error = %v", err)
+				}
+			}
+
+			for _, p := range g.Board.Pieces {
+				if p.Width > tt.wantMax || p.Height > tt.wantMax {
+					t.Errorf("piece %dx%d is larger than the dice allow", p.Width, p.Height)
+				}
+			}
+		})
+	}
+}
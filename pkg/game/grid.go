@@ -0,0 +1,157 @@
+package game
+
+import "math"
+
+// grid records which piece covers each cell of a window of the board, so overlap and adjacency checks are a single
+// lookup per cell instead of a scan over every piece on the board.
+type grid struct {
+	left   int
+	top    int
+	width  int
+	height int
+	pieces []Piece
+	// cells holds the index of the covering piece in pieces plus one, row by row. Zero means the cell is free.
+	cells []int32
+}
+
+// occupancy builds the grid of the whole board.
+func (b *Board) occupancy() grid {
+	return b.occupancyOf(1, 1, int(b.Width), int(b.Height))
+}
+
+// occupancyAround builds the grid of the cells of the piece and the ones bordering it, which is all a single placement
+// check needs to look at.
+func (b *Board) occupancyAround(p Piece) grid {
+	return b.occupancyOf(int(p.Origin.X)-1, int(p.Origin.Y)-1, int(p.Width)+2, int(p.Height)+2)
+}
+
+func (b *Board) occupancyOf(left int, top int, width int, height int) grid {
+	g := grid{
+		left:   left,
+		top:    top,
+		width:  width,
+		height: height,
+		pieces: b.Pieces,
+		cells:  make([]int32, width*height),
+	}
+
+	for i, p := range b.Pieces {
+		// Only fill in the part of the piece that overlaps the window.
+		fromX, toX := maxInt(int(p.Origin.X), left), minInt(int(p.Origin.X)+int(p.Width), left+width)
+		fromY, toY := maxInt(int(p.Origin.Y), top), minInt(int(p.Origin.Y)+int(p.Height), top+height)
+
+		for y := fromY; y < toY; y++ {
+			for x := fromX; x < toX; x++ {
+				g.cells[g.index(x, y)] = int32(i + 1)
+			}
+		}
+	}
+
+	return g
+}
+
+func (g grid) contains(x int, y int) bool {
+	return x >= g.left && x < g.left+g.width && y >= g.top && y < g.top+g.height
+}
+
+func (g grid) index(x int, y int) int {
+	return (y-g.top)*g.width + x - g.left
+}
+
+// isFree checks whether a cell of the board is not covered by any piece. Cells off the board are not free.
+func (g grid) isFree(x int, y int) bool {
+	return g.contains(x, y) && g.cells[g.index(x, y)] == 0
+}
+
+// owner returns the ID of the player whose piece covers the cell, or an empty string if there's none.
+func (g grid) owner(x int, y int) string {
+	if !g.contains(x, y) {
+		return ""
+	}
+	i := g.cells[g.index(x, y)]
+	if i == 0 {
+		return ""
+	}
+	return g.pieces[i-1].Player
+}
+
+// piece returns a copy of the piece covering the cell, or nil if there's none.
+func (g grid) piece(x int, y int) *Piece {
+	if !g.contains(x, y) {
+		return nil
+	}
+	i := g.cells[g.index(x, y)]
+	if i == 0 {
+		return nil
+	}
+	p := g.pieces[i-1]
+	return &p
+}
+
+// area returns the number of cells covered by the player.
+func (g grid) area(playerID string) uint {
+	var area uint
+	for _, i := range g.cells {
+		if i != 0 && g.pieces[i-1].Player == playerID {
+			area++
+		}
+	}
+	return area
+}
+
+// canPlace checks whether a piece is on the board and the ruleset of the board allows it there. The grid has to
+// cover the piece and its borders, the fences are shared by all checks on the same board. Opened is whether the
+// player has pieces on the board already. Only the player, origin and dimensions of the piece are used, the derived
+// fields don't need to be filled in.
+func (b *Board) canPlace(g grid, f *fences, p Piece, opened bool) bool {
+	left, top := int(p.Origin.X), int(p.Origin.Y)
+	right, bottom := left+int(p.Width)-1, top+int(p.Height)-1
+
+	// If any of the coordinates of the new piece are outside of the bounds of the board, it can't be placed.
+	if right > math.MaxUint8 || bottom > math.MaxUint8 {
+		return false
+	}
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			if !b.IsCoordinateWithin(Coordinate{X: uint8(x), Y: uint8(y)}) {
+				return false
+			}
+		}
+	}
+
+	r := b.ruleset()
+
+	// A square the doubles rule lets go anywhere only has to be on free cells, and out of protected territory.
+	if b.anywhere(&p) {
+		for y := top; y <= bottom; y++ {
+			for x := left; x <= right; x++ {
+				if !g.isFree(x, y) {
+					return false
+				}
+			}
+		}
+		rules, ok := r.(Rules)
+		return !(ok && rules.ProtectTerritory && trespasses(f, &p))
+	}
+
+	switch r := r.(type) {
+	case Rules:
+		return r.allows(b, g, f, &p, opened)
+	default:
+		return r.CanPlace(b, p, opened)
+	}
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,270 @@
+package game
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// headerSection writes and reads the tags of a game record that hold a part of the config. Write leaves out tags the
+// game doesn't need, read leaves the config as it is if they're missing.
+type headerSection struct {
+	write func(w io.Writer, g *Game) error
+	read  func(tags map[string]string, c *Config) error
+}
+
+// headerSections are the parts of the config in the header of a game record, in the order they are written.
+var headerSections = []headerSection{
+	{write: writeSeedTag, read: readSeedTag},
+	{write: writeMapTags, read: readMapTags},
+	{write: writeDiceTag, read: readDiceTag},
+	{write: writeBoundsTag, read: readBoundsTag},
+	{write: writeDoublesTag, read: readDoublesTag},
+	{write: writeTimeTag, read: readTimeTag},
+	{write: writeFairnessTags, read: readFairTag},
+	{write: writeRulesetTag, read: readRulesetTag},
+	{write: writePassLimitTag, read: readPassLimitTag},
+	{write: writeScorerTag, read: readScorerTag},
+}
+
+func writeSeedTag(w io.Writer, g *Game) error {
+	if g.Config.Seed != 0 {
+		writeTag(w, "Seed", strconv.FormatInt(g.Config.Seed, 10))
+	}
+	return nil
+}
+
+func readSeedTag(tags map[string]string, c *Config) error {
+	seed, ok := tags["Seed"]
+	if !ok {
+		return nil
+	}
+	var err error
+	if c.Seed, err = strconv.ParseInt(seed, 10, 64); err != nil {
+		return fmt.Errorf("bad seed %q", seed)
+	}
+	return nil
+}
+
+func writeMapTags(w io.Writer, g *Game) error {
+	m := g.Config.Map
+	if m == nil {
+		return nil
+	}
+	writeTag(w, "Map", m.Name)
+	if builtin, ok := BuiltinMap(m.Name); !ok || !reflect.DeepEqual(builtin, m) {
+		writeTag(w, "MapRows", strings.Join(m.Rows(), "/"))
+	}
+	return nil
+}
+
+func readMapTags(tags map[string]string, c *Config) error {
+	name, ok := tags["Map"]
+	if !ok {
+		return nil
+	}
+	if rows, ok := tags["MapRows"]; ok {
+		var err error
+		c.Map, err = newMap(name, strings.Split(rows, "/"))
+		return err
+	}
+	if c.Map, ok = BuiltinMap(name); !ok {
+		return fmt.Errorf("unknown map %q", name)
+	}
+	return nil
+}
+
+func writeDiceTag(w io.Writer, g *Game) error {
+	if g.Config.Dice == nil {
+		return nil
+	}
+	faces := make([]string, 0, len(g.Config.Dice))
+	for _, n := range g.Config.Dice {
+		faces = append(faces, strconv.Itoa(int(n)))
+	}
+	writeTag(w, "Dice", strings.Join(faces, " "))
+	return nil
+}
+
+func readDiceTag(tags map[string]string, c *Config) error {
+	dice, ok := tags["Dice"]
+	if !ok {
+		return nil
+	}
+	for _, field := range strings.Fields(dice) {
+		n, err := strconv.ParseUint(field, 10, 8)
+		if err != nil {
+			return fmt.Errorf("bad dice %q", dice)
+		}
+		c.Dice = append(c.Dice, uint8(n))
+	}
+	if c.Dice == nil {
+		return fmt.Errorf("bad dice %q", dice)
+	}
+	return nil
+}
+
+func writeBoundsTag(w io.Writer, g *Game) error {
+	if b := g.Config.Bounds; b != (Bounds{}) {
+		writeTag(w, "Bounds", fmt.Sprintf("%dx%d %dx%d", b.MinWidth, b.MinHeight, b.MaxWidth, b.MaxHeight))
+	}
+	return nil
+}
+
+func readBoundsTag(tags map[string]string, c *Config) error {
+	bounds, ok := tags["Bounds"]
+	if !ok {
+		return nil
+	}
+	b := &c.Bounds
+	if _, err := fmt.Sscanf(bounds, "%dx%d %dx%d", &b.MinWidth, &b.MinHeight, &b.MaxWidth, &b.MaxHeight); err != nil {
+		return fmt.Errorf("bad bounds %q", bounds)
+	}
+	return nil
+}
+
+func writeDoublesTag(w io.Writer, g *Game) error {
+	if g.Config.Doubles != DoublesNoBonus {
+		writeTag(w, "Doubles", g.Config.Doubles.String())
+	}
+	return nil
+}
+
+func readDoublesTag(tags map[string]string, c *Config) error {
+	doubles, ok := tags["Doubles"]
+	if !ok {
+		return nil
+	}
+	var err error
+	c.Doubles, err = parseDoubles(doubles)
+	return err
+}
+
+func writeTimeTag(w io.Writer, g *Game) error {
+	if tc := g.Config.Time; tc.enabled() {
+		writeTag(w, "Time", formatTimeControl(tc))
+	}
+	return nil
+}
+
+func readTimeTag(tags map[string]string, c *Config) error {
+	tc, ok := tags["Time"]
+	if !ok {
+		return nil
+	}
+	var err error
+	c.Time, err = parseTimeControl(tc)
+	return err
+}
+
+// writeFairnessTags writes the commitment and entropy of a game with fair dice, and the server seed once it's over.
+func writeFairnessTags(w io.Writer, g *Game) error {
+	fj := g.encodeFairness(false)
+	if !g.Config.Fair || fj == nil {
+		return nil
+	}
+	writeTag(w, "Commitment", fj.Commitment)
+	for i, e := range fj.Entropy {
+		if e != "" {
+			writeTag(w, fmt.Sprintf("Entropy%d", i+1), e)
+		}
+	}
+	if fj.Seed != "" {
+		writeTag(w, "ServerSeed", fj.Seed)
+	}
+	return nil
+}
+
+// readFairTag reads whether the game has fair dice. The scheme itself is read by readFairness once the game is made.
+func readFairTag(tags map[string]string, c *Config) error {
+	_, c.Fair = tags["Commitment"]
+	return nil
+}
+
+// readFairness reads the fairness scheme of a game with fair dice.
+func (g *Game) readFairness(tags map[string]string) error {
+	if !g.Config.Fair {
+		return nil
+	}
+	fj := &fairnessJSON{Commitment: tags["Commitment"], Seed: tags["ServerSeed"]}
+	for i := range g.Board.Players {
+		fj.Entropy = append(fj.Entropy, tags[fmt.Sprintf("Entropy%d", i+1)])
+	}
+	var err error
+	g.Fairness, err = decodeFairness(fj, len(g.Board.Players))
+	return err
+}
+
+func writeRulesetTag(w io.Writer, g *Game) error {
+	ruleset, err := formatRuleset(g.Config.Ruleset)
+	if err != nil {
+		return err
+	}
+	writeTag(w, "Ruleset", ruleset)
+	return nil
+}
+
+func readRulesetTag(tags map[string]string, c *Config) error {
+	ruleset, ok := tags["Ruleset"]
+	if !ok {
+		return nil
+	}
+	var err error
+	c.Ruleset, err = parseRuleset(ruleset)
+	return err
+}
+
+func writePassLimitTag(w io.Writer, g *Game) error {
+	if g.Config.PassLimit != 0 {
+		writeTag(w, "PassLimit", strconv.Itoa(g.Config.PassLimit))
+	}
+	return nil
+}
+
+func readPassLimitTag(tags map[string]string, c *Config) error {
+	limit, ok := tags["PassLimit"]
+	if !ok {
+		return nil
+	}
+	var err error
+	if c.PassLimit, err = strconv.Atoi(limit); err != nil {
+		return fmt.Errorf("bad pass limit %q", limit)
+	}
+	return nil
+}
+
+func writeScorerTag(w io.Writer, g *Game) error {
+	if g.Config.Scorer == nil {
+		return nil
+	}
+	sj, err := encodeScorer(g.Config.Scorer)
+	if err != nil {
+		return err
+	}
+	writeTag(w, "Scorer", strings.TrimSpace(fmt.Sprintf("%s %s", sj.Name, formatBonus(sj.Bonus))))
+	return nil
+}
+
+func readScorerTag(tags map[string]string, c *Config) error {
+	scorer, ok := tags["Scorer"]
+	if !ok {
+		return nil
+	}
+	fields := strings.Fields(scorer)
+	if len(fields) == 0 || len(fields) > 2 {
+		return fmt.Errorf("bad scorer %q", scorer)
+	}
+	sj := scorerJSON{Name: fields[0]}
+	if len(fields) == 2 {
+		bonus, err := strconv.ParseUint(fields[1], 10, 0)
+		if err != nil {
+			return fmt.Errorf("bad scorer %q", scorer)
+		}
+		sj.Bonus = uint(bonus)
+	}
+	var err error
+	c.Scorer, err = decodeScorer(&sj)
+	return err
+}
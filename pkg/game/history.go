@@ -0,0 +1,236 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MoveKind is the type of a move in the history of a game.
+type MoveKind uint8
+
+const (
+	// MoveRoll is the current player rolling the dice.
+	MoveRoll MoveKind = iota + 1
+	// MovePlace is the current player placing the rolled piece.
+	MovePlace
+	// MovePass is the current player giving up their roll.
+	MovePass
+	// MoveTimeout is the current player running out of time.
+	MoveTimeout
+)
+
+func (k MoveKind) String() string {
+	switch k {
+	case MoveRoll:
+		return "roll"
+	case MovePlace:
+		return "place"
+	case MovePass:
+		return "pass"
+	case MoveTimeout:
+		return "timeout"
+	default:
+		return fmt.Sprintf("unknown move %d", uint8(k))
+	}
+}
+
+// Move is an entry in the history of a game. Only the fields for its kind are set.
+type Move struct {
+	Kind   MoveKind
+	Player string
+	// Roll is what the dice showed for MoveRoll.
+	Roll Roll
+	// Origin and Rotated are where and how the piece went for MovePlace.
+	Origin  Coordinate
+	Rotated bool
+	// Forced is set for a MovePass the engine made because the roll could not be placed anywhere.
+	Forced bool
+}
+
+var (
+	// ErrNothingToUndo is returned when there is no move left to take back.
+	ErrNothingToUndo = errors.New("nothing to undo")
+	// ErrNothingToRedo is returned when there is no taken back move to play again.
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// turnState is everything a move can change, so it can be put back when the move is taken back.
+type turnState struct {
+	pieces  int
+	roll    *Roll
+	scores  []uint
+	phase   Phase
+	current int
+	passes  int
+	ended   EndReason
+	// remaining is the time the players had left, for games with a time control.
+	remaining []time.Duration
+}
+
+// Undo takes back the last move. A pass the engine forced is taken back together with the roll that caused it, and
+// rolling again after a roll was taken back comes up the same. In a timed game the players get back the time they took
+// for the moves, and the clock of the player to move restarts. A game with fair dice can't be taken back once it's over
+// and its server seed is out.
+func (g *Game) Undo() error {
+	if g.Fairness != nil && g.revealed() {
+		return ErrRevealed
+	}
+	if len(g.History) == 0 {
+		return ErrNothingToUndo
+	}
+	defer g.restartClock()
+
+	for {
+		last := len(g.History) - 1
+		m := g.History[last]
+
+		g.restore(g.undo[last])
+		g.History = g.History[:last]
+		g.undo = g.undo[:last]
+		g.redo = append(g.redo, m)
+
+		if !m.Forced || last == 0 {
+			return nil
+		}
+	}
+}
+
+// Redo plays the last move taken back by Undo again. In a timed game nobody is charged for it, and the clock of the
+// player to move restarts. Like Undo, it's refused for a game with fair dice whose server seed is out.
+func (g *Game) Redo() error {
+	if g.Fairness != nil && g.revealed() {
+		return ErrRevealed
+	}
+	if len(g.redo) == 0 {
+		return ErrNothingToRedo
+	}
+	defer g.restartClock()
+
+	for {
+		last := len(g.redo) - 1
+		m := g.redo[last]
+
+		if err := g.apply(m); err != nil {
+			return fmt.Errorf("game.Redo(): %w", err)
+		}
+		g.redo = g.redo[:last]
+
+		if last == 0 || !g.redo[last-1].Forced {
+			return nil
+		}
+	}
+}
+
+// play applies a new move. A new move means the moves that were taken back can't be played again.
+func (g *Game) play(m Move) error {
+	if err := g.apply(m); err != nil {
+		return err
+	}
+	g.redo = nil
+	return nil
+}
+
+// apply makes the move and records it in the history.
+func (g *Game) apply(m Move) error {
+	state := g.state()
+
+	var err error
+	switch m.Kind {
+	case MoveRoll:
+		err = g.roll(m.Player, m.Roll)
+	case MovePlace:
+		err = g.place(m.Player, m.Origin, m.Rotated)
+	case MovePass:
+		err = g.pass(m.Player)
+	case MoveTimeout:
+		err = g.timeout(m.Player)
+	default:
+		err = fmt.Errorf("game: can't apply %s", m.Kind)
+	}
+	if err != nil {
+		return err
+	}
+
+	g.History = append(g.History, m)
+	g.undo = append(g.undo, state)
+	if m.Kind == MoveRoll && g.rolls() > len(g.rolled) {
+		g.rolled = append(g.rolled, m.Roll)
+	}
+	return nil
+}
+
+// nextRoll returns what the dice come up with for the next roll. Like with fair dice, a roll that was taken back comes
+// up the same again, so undoing a bad roll doesn't give another go at it.
+func (g *Game) nextRoll() Roll {
+	if n := g.rolls(); n < len(g.rolled) {
+		return g.rolled[n]
+	}
+	if g.Dice == nil {
+		g.Dice = g.Config.dice()
+	}
+	return g.Dice.Roll()
+}
+
+func (g *Game) state() turnState {
+	s := turnState{
+		pieces:  len(g.Board.Pieces),
+		scores:  make([]uint, len(g.Board.Players)),
+		phase:   g.Phase,
+		current: g.Current,
+		passes:  g.Passes,
+		ended:   g.Ended,
+	}
+
+	if g.Board.Roll != nil {
+		roll := *g.Board.Roll
+		s.roll = &roll
+	}
+
+	for i, p := range g.Board.Players {
+		s.scores[i] = p.Score
+	}
+
+	if g.Remaining != nil {
+		s.remaining = append([]time.Duration(nil), g.Remaining...)
+	}
+
+	return s
+}
+
+func (g *Game) restore(s turnState) {
+	g.Board.Pieces = g.Board.Pieces[:s.pieces:s.pieces]
+	g.Board.Roll = nil
+	if s.roll != nil {
+		roll := *s.roll
+		g.Board.Roll = &roll
+	}
+
+	for i := range g.Board.Players {
+		g.Board.Players[i].Score = s.scores[i]
+	}
+
+	g.Phase = s.phase
+	g.Current = s.current
+	g.Passes = s.passes
+	g.Ended = s.ended
+	if s.remaining != nil {
+		g.Remaining = append([]time.Duration(nil), s.remaining...)
+	}
+}
+
+// resumeDice sets up the dice of a game rebuilt from its history with a seed, so they continue after the rolls that
+// were already made instead of rolling them again. Rolls that were taken back don't count.
+func (g *Game) resumeDice() {
+	if g.Config.Seed == 0 {
+		return
+	}
+
+	d := g.Config.dice()
+	for _, m := range g.History {
+		if m.Kind == MoveRoll {
+			d.Roll()
+		}
+	}
+	g.Dice = d
+}
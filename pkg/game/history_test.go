@@ -0,0 +1,231 @@
+package game_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// copyGame returns a copy of the game that doesn't share pieces or players with it, to compare against later.
+func copyGame(g game.Game) game.Game {
+	c := g
+	c.Board.Pieces = append([]game.Piece{}, g.Board.Pieces...)
+	c.Board.Players = append([]game.Player{}, g.Board.Players...)
+	if g.Board.Roll != nil {
+		roll := *g.Board.Roll
+		c.Board.Roll = &roll
+	}
+	c.History = append([]game.Move(nil), g.History...)
+	return c
+}
+
+func assertSameState(t *testing.T, got game.Game, want game.Game) {
+	t.Helper()
+
+	if !reflect.DeepEqual(got.Board.Pieces, want.Board.Pieces) {
+		t.Errorf("pieces = \n%s, want \n%s", got.Board, want.Board)
+	}
+	if !reflect.DeepEqual(got.Board.Players, want.Board.Players) {
+		t.Errorf("players = %v, want %v", got.Board.Players, want.Board.Players)
+	}
+	if !reflect.DeepEqual(got.Board.Roll, want.Board.Roll) {
+		t.Errorf("roll = %v, want %v", got.Board.Roll, want.Board.Roll)
+	}
+	if len(got.History) != len(want.History) || len(got.History) > 0 && !reflect.DeepEqual(got.History, want.History) {
+		t.Errorf("history = %v, want %v", got.History, want.History)
+	}
+	if got.Phase != want.Phase || got.Current != want.Current || got.Passes != want.Passes || got.Ended != want.Ended {
+		t.Errorf("turn = %v/%d/%d/%v, want %v/%d/%d/%v",
+			got.Phase, got.Current, got.Passes, got.Ended, want.Phase, want.Current, want.Passes, want.Ended)
+	}
+}
+
+func TestGame_History(t *testing.T) {
+	g, err := game.New("1", 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 2, 0, 0}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, true); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Pass("playerTwo"); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	want := []game.Move{
+		{Kind: game.MoveRoll, Player: "playerOne", Roll: game.Roll{Width: 2, Height: 3}},
+		{Kind: game.MovePlace, Player: "playerOne", Origin: game.Coordinate{X: 1, Y: 1}, Rotated: true},
+		{Kind: game.MoveRoll, Player: "playerTwo", Roll: game.Roll{Width: 1, Height: 1}},
+		{Kind: game.MovePass, Player: "playerTwo"},
+	}
+	if !reflect.DeepEqual(g.History, want) {
+		t.Errorf("History = %v, want %v", g.History, want)
+	}
+}
+
+func TestGame_UndoRedo(t *testing.T) {
+	g, err := game.New("1", 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 2, 0, 0, 3, 3}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	steps := []func() error{
+		func() error { _, err := g.Roll("playerOne"); return err },
+		func() error { return g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false) },
+		func() error { _, err := g.Roll("playerTwo"); return err },
+		func() error { return g.Place("playerTwo", game.Coordinate{X: 12, Y: 16}, false) },
+		func() error { _, err := g.Roll("playerOne"); return err },
+		func() error { return g.Place("playerOne", game.Coordinate{X: 3, Y: 1}, false) },
+	}
+
+	states := []game.Game{copyGame(g)}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d error = %v", i, err)
+		}
+		states = append(states, copyGame(g))
+	}
+
+	for i := len(steps) - 1; i >= 0; i-- {
+		if err := g.Undo(); err != nil {
+			t.Fatalf("Undo() error = %v", err)
+		}
+		assertSameState(t, g, states[i])
+	}
+
+	if err := g.Undo(); !errors.Is(err, game.ErrNothingToUndo) {
+		t.Errorf("Undo() error = %v, want %v", err, game.ErrNothingToUndo)
+	}
+
+	for i := 1; i <= len(steps); i++ {
+		if err := g.Redo(); err != nil {
+			t.Fatalf("Redo() error = %v", err)
+		}
+		assertSameState(t, g, states[i])
+	}
+
+	if err := g.Redo(); !errors.Is(err, game.ErrNothingToRedo) {
+		t.Errorf("Redo() error = %v, want %v", err, game.ErrNothingToRedo)
+	}
+}
+
+func TestGame_UndoForcedPass(t *testing.T) {
+	g := newTestGame(t, 6, 1)
+	// Wall player one in with player two's pieces.
+	g.Board.Pieces = append(g.Board.Pieces,
+		mustNewPiece(t, "playerTwo", 2, 1, 1, 2),
+		mustNewPiece(t, "playerTwo", 1, 2, 1, 1),
+	)
+	before := copyGame(g)
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if len(g.History) != 2 || !g.History[1].Forced {
+		t.Fatalf("Roll() history = %v, want a roll and a forced pass", g.History)
+	}
+	after := copyGame(g)
+
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	assertSameState(t, g, before)
+
+	if err := g.Redo(); err != nil {
+		t.Fatalf("Redo() error = %v", err)
+	}
+	assertSameState(t, g, after)
+}
+
+func TestGame_NewMoveClearsRedo(t *testing.T) {
+	g := newTestGame(t, 1, 1)
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+
+	if err := g.Redo(); !errors.Is(err, game.ErrNothingToRedo) {
+		t.Errorf("Redo() error = %v, want %v", err, game.ErrNothingToRedo)
+	}
+}
+
+func TestGame_UndoRoll(t *testing.T) {
+	tests := []struct {
+		name   string
+		config game.Config
+	}{
+		{
+			name:   "seeded dice",
+			config: game.Config{Seed: 42},
+		},
+		{
+			name: "dice seeded from the time",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.NewWithConfig("1", tt.config, 12, 16, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewWithConfig() error = %v", err)
+			}
+			want, err := g.Roll("playerOne")
+			if err != nil {
+				t.Fatalf("Roll() error = %v", err)
+			}
+			if err := g.Undo(); err != nil {
+				t.Fatalf("Undo() error = %v", err)
+			}
+
+			data, err := json.Marshal(g)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var restored game.Game
+			if err := json.Unmarshal(data, &restored); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			got, err := g.Roll("playerOne")
+			if err != nil {
+				t.Fatalf("Roll() error = %v", err)
+			}
+			if got != want {
+				t.Errorf("Roll() after Undo() = %v, want %v", got, want)
+			}
+
+			if tt.config.Seed == 0 {
+				return
+			}
+			// A game restored from its state rolls on from the same spot.
+			if got, err := restored.Roll("playerOne"); err != nil || got != want {
+				t.Errorf("restored Roll() = %v, %v, want %v", got, err, want)
+			}
+		})
+	}
+}
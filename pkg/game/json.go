@@ -0,0 +1,710 @@
+package game
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+)
+
+// jsonVersion is the version of the JSON format games are encoded in. Bump it whenever the format changes in a way
+// older decoders can't read.
+const jsonVersion = 1
+
+// ErrUnsupportedVersion is returned when decoding a game encoded in a format version this package doesn't know.
+var ErrUnsupportedVersion = errors.New("unsupported game format version")
+
+// ErrInconsistentGame is returned when decoding a game whose pieces, scores, turn or history don't add up.
+var ErrInconsistentGame = errors.New("inconsistent game")
+
+type gameJSON struct {
+	Version  int           `json:"version"`
+	Session  string        `json:"session"`
+	Config   configJSON    `json:"config"`
+	Width    uint8         `json:"width"`
+	Height   uint8         `json:"height"`
+	Players  []playerJSON  `json:"players"`
+	Pieces   []pieceJSON   `json:"pieces"`
+	Turn     turnJSON      `json:"turn"`
+	History  []moveJSON    `json:"history"`
+	Roll     *rollJSON     `json:"roll,omitempty"`
+	Clock    *clockJSON    `json:"clock,omitempty"`
+	Fairness *fairnessJSON `json:"fairness,omitempty"`
+}
+
+// fairnessJSON is the fairness scheme with its bytes in hex. The server seed is left out until the game is over unless
+// the game is encoded to be stored, the entropy of players who haven't contributed yet is empty.
+type fairnessJSON struct {
+	Commitment string   `json:"commitment"`
+	Entropy    []string `json:"entropy,omitempty"`
+	Seed       string   `json:"seed,omitempty"`
+}
+
+type configJSON struct {
+	PassLimit int          `json:"passLimit,omitempty"`
+	Scorer    *scorerJSON  `json:"scorer,omitempty"`
+	Seed      int64        `json:"seed,omitempty"`
+	Ruleset   *rulesetJSON `json:"ruleset,omitempty"`
+	Map       *Map         `json:"map,omitempty"`
+	Dice      []int        `json:"dice,omitempty"`
+	Bounds    *boundsJSON  `json:"bounds,omitempty"`
+	Doubles   string       `json:"doubles,omitempty"`
+	Time      *timeJSON    `json:"time,omitempty"`
+	Fair      bool         `json:"fair,omitempty"`
+}
+
+// timeJSON is a time control with its durations in milliseconds.
+type timeJSON struct {
+	Base      int64  `json:"baseMs,omitempty"`
+	Increment int64  `json:"incrementMs,omitempty"`
+	PerMove   int64  `json:"perMoveMs,omitempty"`
+	OnTimeout string `json:"onTimeout"`
+}
+
+// clockJSON is the time the players have left when the game was encoded, in milliseconds.
+type clockJSON struct {
+	// Remaining is the time each player has left for the rest of the game, if the time control has a base time.
+	Remaining []int64 `json:"remainingMs,omitempty"`
+	// TurnLeft is the time the current player has left for their turn. It's only for showing to players.
+	TurnLeft int64 `json:"turnLeftMs"`
+	// TurnStarted is when the clock of the turn in progress started, so decoding carries on with it. TurnUsed is the
+	// time the current player had used of it, which Remaining already takes off.
+	TurnStarted *time.Time `json:"turnStarted,omitempty"`
+	TurnUsed    int64      `json:"turnUsedMs,omitempty"`
+}
+
+type boundsJSON struct {
+	MinWidth  uint8 `json:"minWidth"`
+	MaxWidth  uint8 `json:"maxWidth"`
+	MinHeight uint8 `json:"minHeight"`
+	MaxHeight uint8 `json:"maxHeight"`
+}
+
+type rulesetJSON struct {
+	CornerTouch      bool   `json:"cornerTouch,omitempty"`
+	Opponents        string `json:"opponents,omitempty"`
+	OverlapOwn       bool   `json:"overlapOwn,omitempty"`
+	ProtectTerritory bool   `json:"protectTerritory,omitempty"`
+}
+
+type scorerJSON struct {
+	Name  string `json:"name"`
+	Bonus uint   `json:"bonus,omitempty"`
+}
+
+type playerJSON struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Score uint   `json:"score"`
+}
+
+// pieceJSON is a piece by its origin and size only, the derived fields are rebuilt when decoding.
+type pieceJSON struct {
+	Player string `json:"player"`
+	X      uint8  `json:"x"`
+	Y      uint8  `json:"y"`
+	Width  uint8  `json:"width"`
+	Height uint8  `json:"height"`
+}
+
+type turnJSON struct {
+	Phase   string `json:"phase"`
+	Current int    `json:"current"`
+	Passes  int    `json:"passes"`
+	Ended   string `json:"ended,omitempty"`
+}
+
+type rollJSON struct {
+	Width  uint8 `json:"width"`
+	Height uint8 `json:"height"`
+}
+
+// decode returns the roll, which has to be something the dice can roll.
+func (rj rollJSON) decode(faces Faces) (Roll, error) {
+	if !faces.Has(rj.Width) || !faces.Has(rj.Height) {
+		return Roll{}, fmt.Errorf("%w: dice with faces %v can't roll %dx%d", ErrInconsistentGame, faces, rj.Width, rj.Height)
+	}
+	return Roll{Width: rj.Width, Height: rj.Height}, nil
+}
+
+type moveJSON struct {
+	Kind    string    `json:"kind"`
+	Player  string    `json:"player"`
+	Roll    *rollJSON `json:"roll,omitempty"`
+	X       uint8     `json:"x,omitempty"`
+	Y       uint8     `json:"y,omitempty"`
+	Rotated bool      `json:"rotated,omitempty"`
+	Forced  bool      `json:"forced,omitempty"`
+}
+
+// MarshalJSON encodes the game in the versioned JSON format. Pieces are stored by origin and size, the dice and the
+// moves that were taken back are not stored. With a time control, the time the players have left is stored as it is
+// at the moment of encoding. The server seed of fair dice is left out until the game is over, so this is what can be
+// shown to players. Use MarshalPrivateJSON to store a game that is still going.
+func (g Game) MarshalJSON() ([]byte, error) {
+	gj, err := g.encode(false)
+	if err != nil {
+		return nil, fmt.Errorf("game.MarshalJSON(): %w", err)
+	}
+	return json.Marshal(gj)
+}
+
+// MarshalPrivateJSON encodes the game like MarshalJSON, but keeps the server seed of fair dice, so the game can be
+// stored and picked up again with UnmarshalJSON before it's over. It's for the server only, players must not see it.
+func (g Game) MarshalPrivateJSON() ([]byte, error) {
+	gj, err := g.encode(true)
+	if err != nil {
+		return nil, fmt.Errorf("game.MarshalPrivateJSON(): %w", err)
+	}
+	return json.Marshal(gj)
+}
+
+// encode encodes the game, with the server seed of fair dice if it's private or revealed.
+func (g Game) encode(private bool) (gameJSON, error) {
+	scorer, err := encodeScorer(g.Config.Scorer)
+	if err != nil {
+		return gameJSON{}, err
+	}
+
+	ruleset, err := encodeRuleset(g.Config.Ruleset)
+	if err != nil {
+		return gameJSON{}, err
+	}
+
+	gj := gameJSON{
+		Version: jsonVersion,
+		Session: g.Session,
+		Config: configJSON{
+			PassLimit: g.Config.PassLimit,
+			Scorer:    scorer,
+			Seed:      g.Config.Seed,
+			Ruleset:   ruleset,
+			Map:       g.Config.Map,
+			Dice:      encodeFaces(g.Config.Dice),
+			Bounds:    encodeBounds(g.Config.Bounds),
+		},
+		Width:   g.Board.Width,
+		Height:  g.Board.Height,
+		Players: make([]playerJSON, 0, len(g.Board.Players)),
+		Pieces:  make([]pieceJSON, 0, len(g.Board.Pieces)),
+		Turn: turnJSON{
+			Phase:   g.Phase.String(),
+			Current: g.Current,
+			Passes:  g.Passes,
+		},
+		History: make([]moveJSON, 0, len(g.History)),
+	}
+
+	if g.Config.Doubles != DoublesNoBonus {
+		gj.Config.Doubles = g.Config.Doubles.String()
+	}
+
+	if g.Config.Fair {
+		gj.Config.Fair = true
+		gj.Fairness = g.encodeFairness(private)
+	}
+
+	if tc := g.Config.Time; tc.enabled() {
+		gj.Config.Time = &timeJSON{Base: tc.Base.Milliseconds(), Increment: tc.Increment.Milliseconds(), PerMove: tc.PerMove.Milliseconds(), OnTimeout: tc.OnTimeout.String()}
+		gj.Clock = g.encodeClock()
+	}
+
+	if g.Ended != NotEnded {
+		gj.Turn.Ended = g.Ended.String()
+	}
+
+	if g.Board.Roll != nil {
+		gj.Roll = &rollJSON{Width: g.Board.Roll.Width, Height: g.Board.Roll.Height}
+	}
+
+	for _, p := range g.Board.Players {
+		gj.Players = append(gj.Players, playerJSON{ID: p.ID, Name: p.Name, Score: p.Score})
+	}
+
+	for _, p := range g.Board.Pieces {
+		gj.Pieces = append(gj.Pieces, pieceJSON{Player: p.Player, X: p.Origin.X, Y: p.Origin.Y, Width: p.Width, Height: p.Height})
+	}
+
+	for _, m := range g.History {
+		mj := moveJSON{Kind: m.Kind.String(), Player: m.Player, Rotated: m.Rotated, Forced: m.Forced}
+		switch m.Kind {
+		case MoveRoll:
+			mj.Roll = &rollJSON{Width: m.Roll.Width, Height: m.Roll.Height}
+		case MovePlace:
+			mj.X, mj.Y = m.Origin.X, m.Origin.Y
+		}
+		gj.History = append(gj.History, mj)
+	}
+
+	return gj, nil
+}
+
+// UnmarshalJSON decodes a game in the versioned JSON format. The board is rebuilt piece by piece with the placement
+// rules, and if there is a history, it is replayed and has to end up in the same position as the stored one. Anything
+// that doesn't add up is rejected with ErrInconsistentGame.
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var gj gameJSON
+	if err := json.Unmarshal(data, &gj); err != nil {
+		return fmt.Errorf("game.UnmarshalJSON(): %w", err)
+	}
+
+	if gj.Version != jsonVersion {
+		return fmt.Errorf("game.UnmarshalJSON(): %w: %d", ErrUnsupportedVersion, gj.Version)
+	}
+
+	decoded, err := gj.decode()
+	if err != nil {
+		return fmt.Errorf("game.UnmarshalJSON(): %w", err)
+	}
+
+	*g = decoded
+	return nil
+}
+
+func (gj gameJSON) decode() (Game, error) {
+	config, err := gj.Config.decode()
+	if err != nil {
+		return Game{}, err
+	}
+
+	ids := make([]string, 0, len(gj.Players))
+	for _, p := range gj.Players {
+		ids = append(ids, p.ID)
+	}
+
+	g, err := NewWithConfig(gj.Session, config, gj.Width, gj.Height, ids...)
+	if err != nil {
+		return Game{}, err
+	}
+	for i, p := range gj.Players {
+		g.Board.Players[i].Name = p.Name
+	}
+	if err := g.decodeClock(gj.Clock, gj.Turn.Current); err != nil {
+		return Game{}, err
+	}
+	if g.Config.Fair {
+		if g.Fairness, err = decodeFairness(gj.Fairness, len(g.Board.Players)); err != nil {
+			return Game{}, err
+		}
+	}
+
+	want, err := gj.position(g)
+	if err != nil {
+		return Game{}, err
+	}
+
+	if len(gj.History) == 0 {
+		return want, nil
+	}
+
+	if err := gj.replay(&g); err != nil {
+		return Game{}, err
+	}
+	if err := samePosition(g, want); err != nil {
+		return Game{}, err
+	}
+
+	g.resumeDice()
+	return g, nil
+}
+
+func (cj configJSON) decode() (Config, error) {
+	scorer, err := decodeScorer(cj.Scorer)
+	if err != nil {
+		return Config{}, err
+	}
+
+	ruleset, err := decodeRuleset(cj.Ruleset)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config := Config{PassLimit: cj.PassLimit, Scorer: scorer, Seed: cj.Seed, Ruleset: ruleset, Map: cj.Map, Fair: cj.Fair}
+	if config.Dice, err = decodeFaces(cj.Dice); err != nil {
+		return Config{}, err
+	}
+	if b := cj.Bounds; b != nil {
+		config.Bounds = Bounds{MinWidth: b.MinWidth, MaxWidth: b.MaxWidth, MinHeight: b.MinHeight, MaxHeight: b.MaxHeight}
+	}
+	if cj.Doubles != "" {
+		if config.Doubles, err = parseDoubles(cj.Doubles); err != nil {
+			return Config{}, err
+		}
+	}
+	if tj := cj.Time; tj != nil {
+		config.Time = TimeControl{
+			Base:      time.Duration(tj.Base) * time.Millisecond,
+			Increment: time.Duration(tj.Increment) * time.Millisecond,
+			PerMove:   time.Duration(tj.PerMove) * time.Millisecond,
+		}
+		if config.Time.OnTimeout, err = parseTimeout(tj.OnTimeout); err != nil {
+			return Config{}, err
+		}
+	}
+
+	return config, nil
+}
+
+// replay plays the stored history on the game.
+func (gj gameJSON) replay(g *Game) error {
+	for i, mj := range gj.History {
+		m, err := mj.decode(g.Board.dice())
+		if err != nil {
+			return fmt.Errorf("move %d: %w", i+1, err)
+		}
+		if err := g.apply(m); err != nil {
+			return fmt.Errorf("%w: move %d: %v", ErrInconsistentGame, i+1, err)
+		}
+	}
+	return nil
+}
+
+// position builds the stored position on top of an empty game, without looking at the history.
+func (gj gameJSON) position(empty Game) (Game, error) {
+	g := empty
+	g.Board.Players = append([]Player(nil), empty.Board.Players...)
+	g.Board.Pieces = []Piece{}
+
+	for i, pj := range gj.Pieces {
+		p, err := g.Board.dice().NewPiece(pj.Player, pj.X, pj.Y, pj.Width, pj.Height)
+		if err != nil {
+			return Game{}, fmt.Errorf("%w: piece %d: %v", ErrInconsistentGame, i+1, err)
+		}
+		if _, err := g.Board.PlacePiece(p); err != nil {
+			return Game{}, fmt.Errorf("%w: piece %d: %v", ErrInconsistentGame, i+1, err)
+		}
+	}
+
+	for i, p := range gj.Players {
+		if g.Board.Players[i].Score != p.Score {
+			return Game{}, fmt.Errorf("%w: player %q has a score of %d, but their pieces are worth %d", ErrInconsistentGame, p.ID, p.Score, g.Board.Players[i].Score)
+		}
+	}
+
+	phase, err := parsePhase(gj.Turn.Phase)
+	if err != nil {
+		return Game{}, err
+	}
+	ended := NotEnded
+	if gj.Turn.Ended != "" {
+		if ended, err = parseEndReason(gj.Turn.Ended); err != nil {
+			return Game{}, err
+		}
+	}
+
+	if gj.Turn.Current < 0 || gj.Turn.Current >= len(g.Board.Players) {
+		return Game{}, fmt.Errorf("%w: current player %d out of range", ErrInconsistentGame, gj.Turn.Current)
+	}
+	if (phase == AwaitingPlacement) != (gj.Roll != nil) {
+		return Game{}, fmt.Errorf("%w: %s with roll %v", ErrInconsistentGame, phase, gj.Roll)
+	}
+	if (phase == GameOver) != (ended != NotEnded) {
+		return Game{}, fmt.Errorf("%w: %s but ended %s", ErrInconsistentGame, phase, ended)
+	}
+
+	g.Phase = phase
+	g.Current = gj.Turn.Current
+	g.Passes = gj.Turn.Passes
+	g.Ended = ended
+	if gj.Roll != nil {
+		roll, err := gj.Roll.decode(g.Board.dice())
+		if err != nil {
+			return Game{}, err
+		}
+		g.Board.Roll = &roll
+	}
+
+	return g, nil
+}
+
+// samePosition checks whether replaying the history ended up where the stored position is.
+func samePosition(got Game, want Game) error {
+	switch {
+	case !reflect.DeepEqual(got.Board.Pieces, want.Board.Pieces):
+		return fmt.Errorf("%w: history does not lead to the stored pieces", ErrInconsistentGame)
+	case !reflect.DeepEqual(got.Board.Roll, want.Board.Roll):
+		return fmt.Errorf("%w: history does not lead to the stored roll", ErrInconsistentGame)
+	case got.Phase != want.Phase || got.Current != want.Current || got.Passes != want.Passes || got.Ended != want.Ended:
+		return fmt.Errorf("%w: history does not lead to the stored turn", ErrInconsistentGame)
+	}
+	return nil
+}
+
+func (mj moveJSON) decode(faces Faces) (Move, error) {
+	kind, err := parseMoveKind(mj.Kind)
+	if err != nil {
+		return Move{}, err
+	}
+
+	m := Move{Kind: kind, Player: mj.Player, Rotated: mj.Rotated, Forced: mj.Forced}
+	switch kind {
+	case MoveRoll:
+		if mj.Roll == nil {
+			return Move{}, fmt.Errorf("%w: roll without dice", ErrInconsistentGame)
+		}
+		if m.Roll, err = mj.Roll.decode(faces); err != nil {
+			return Move{}, err
+		}
+	case MovePlace:
+		m.Origin = Coordinate{X: mj.X, Y: mj.Y}
+	}
+
+	return m, nil
+}
+
+func encodeScorer(s Scorer) (*scorerJSON, error) {
+	switch s := s.(type) {
+	case nil:
+		return nil, nil
+	case AreaScorer:
+		return &scorerJSON{Name: "area"}, nil
+	case RowBonusScorer:
+		return &scorerJSON{Name: "rows", Bonus: s.Bonus}, nil
+	case TerritoryScorer:
+		return &scorerJSON{Name: "territory"}, nil
+	default:
+		return nil, fmt.Errorf("can't encode scorer %T", s)
+	}
+}
+
+func decodeScorer(sj *scorerJSON) (Scorer, error) {
+	if sj == nil {
+		return nil, nil
+	}
+
+	switch sj.Name {
+	case "area":
+		return AreaScorer{}, nil
+	case "rows":
+		return RowBonusScorer{Bonus: sj.Bonus}, nil
+	case "territory":
+		return TerritoryScorer{}, nil
+	default:
+		return nil, fmt.Errorf("unknown scorer %q", sj.Name)
+	}
+}
+
+// encodeClock encodes the time the players have left right now, so the turn in progress is taken off the time of the
+// current player.
+func (g *Game) encodeClock() *clockJSON {
+	cj := &clockJSON{}
+	now := g.clock().Now()
+	if left, ok := g.TimeLeft(); ok {
+		started := g.turnStarted
+		cj.TurnLeft = left.Milliseconds()
+		cj.TurnStarted = &started
+		cj.TurnUsed = now.Sub(started).Milliseconds()
+	}
+
+	if g.Remaining != nil {
+		cj.Remaining = make([]int64, 0, len(g.Remaining))
+		for i, r := range g.Remaining {
+			if i == g.Current && g.Phase != GameOver {
+				if r -= now.Sub(g.turnStarted); r < 0 {
+					r = 0
+				}
+			}
+			cj.Remaining = append(cj.Remaining, r.Milliseconds())
+		}
+	}
+
+	return cj
+}
+
+// decodeClock sets the time the players have left on a new game, and carries on with the clock of the turn in progress
+// of the current player.
+func (g *Game) decodeClock(cj *clockJSON, current int) error {
+	if cj == nil {
+		return nil
+	}
+	if cj.TurnStarted != nil {
+		g.turnStarted = *cj.TurnStarted
+	}
+	if cj.Remaining == nil {
+		return nil
+	}
+	if len(cj.Remaining) != len(g.Remaining) {
+		return fmt.Errorf("%w: time left for %d players, but there are %d with a base time", ErrInconsistentGame, len(cj.Remaining), len(g.Remaining))
+	}
+	for i, r := range cj.Remaining {
+		g.Remaining[i] = time.Duration(r) * time.Millisecond
+		if i == current && cj.TurnStarted != nil {
+			g.Remaining[i] += time.Duration(cj.TurnUsed) * time.Millisecond
+		}
+	}
+	return nil
+}
+
+// encodeFairness encodes the fairness scheme, with the server seed if it's private or the game is over.
+func (g *Game) encodeFairness(private bool) *fairnessJSON {
+	f := g.Fairness
+	if f == nil {
+		return nil
+	}
+
+	fj := &fairnessJSON{Commitment: hex.EncodeToString(f.Commitment)}
+	for _, e := range f.Entropy {
+		fj.Entropy = append(fj.Entropy, hex.EncodeToString(e))
+	}
+	if private || g.revealed() {
+		fj.Seed = hex.EncodeToString(f.Seed)
+	}
+	return fj
+}
+
+func decodeFairness(fj *fairnessJSON, players int) (*Fairness, error) {
+	if fj == nil {
+		return nil, fmt.Errorf("%w: fair dice without a commitment", ErrInconsistentGame)
+	}
+
+	var f Fairness
+	var err error
+	if f.Commitment, err = hex.DecodeString(fj.Commitment); err != nil || len(f.Commitment) != sha256.Size {
+		return nil, fmt.Errorf("%w: bad commitment %q", ErrInconsistentGame, fj.Commitment)
+	}
+	if fj.Entropy != nil {
+		if len(fj.Entropy) != players {
+			return nil, fmt.Errorf("%w: entropy of %d players, but there are %d", ErrInconsistentGame, len(fj.Entropy), players)
+		}
+		f.Entropy = make([][]byte, players)
+		for i, e := range fj.Entropy {
+			if e == "" {
+				continue
+			}
+			if f.Entropy[i], err = hex.DecodeString(e); err != nil {
+				return nil, fmt.Errorf("%w: bad entropy %q", ErrInconsistentGame, e)
+			}
+		}
+	}
+	if fj.Seed != "" {
+		if f.Seed, err = hex.DecodeString(fj.Seed); err != nil {
+			return nil, fmt.Errorf("%w: bad server seed %q", ErrInconsistentGame, fj.Seed)
+		}
+		if commitment := sha256.Sum256(f.Seed); !bytes.Equal(commitment[:], f.Commitment) {
+			return nil, fmt.Errorf("%w: server seed does not hash to the commitment", ErrInconsistentGame)
+		}
+	}
+
+	return &f, nil
+}
+
+func encodeFaces(f Faces) []int {
+	if f == nil {
+		return nil
+	}
+	faces := make([]int, 0, len(f))
+	for _, n := range f {
+		faces = append(faces, int(n))
+	}
+	return faces
+}
+
+func decodeFaces(faces []int) (Faces, error) {
+	if faces == nil {
+		return nil, nil
+	}
+	f := make(Faces, 0, len(faces))
+	for _, n := range faces {
+		if n < 1 || n > math.MaxUint8 {
+			return nil, fmt.Errorf("bad die face %d", n)
+		}
+		f = append(f, uint8(n))
+	}
+	return f, nil
+}
+
+func encodeBounds(b Bounds) *boundsJSON {
+	if b == (Bounds{}) {
+		return nil
+	}
+	return &boundsJSON{MinWidth: b.MinWidth, MaxWidth: b.MaxWidth, MinHeight: b.MinHeight, MaxHeight: b.MaxHeight}
+}
+
+func encodeRuleset(r Ruleset) (*rulesetJSON, error) {
+	switch r := r.(type) {
+	case nil:
+		return nil, nil
+	case Rules:
+		rj := &rulesetJSON{CornerTouch: r.CornerTouch, OverlapOwn: r.OverlapOwn, ProtectTerritory: r.ProtectTerritory}
+		if r.Opponents != ContactAllowed {
+			rj.Opponents = r.Opponents.String()
+		}
+		return rj, nil
+	default:
+		return nil, fmt.Errorf("can't encode ruleset %T", r)
+	}
+}
+
+func decodeRuleset(rj *rulesetJSON) (Ruleset, error) {
+	if rj == nil {
+		return nil, nil
+	}
+
+	r := Rules{CornerTouch: rj.CornerTouch, OverlapOwn: rj.OverlapOwn, ProtectTerritory: rj.ProtectTerritory}
+	if rj.Opponents != "" {
+		var err error
+		if r.Opponents, err = parseContact(rj.Opponents); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+func parsePhase(s string) (Phase, error) {
+	for p := AwaitingRoll; p <= GameOver; p++ {
+		if p.String() == s {
+			return p, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown phase %q", s)
+}
+
+func parseEndReason(s string) (EndReason, error) {
+	for r := NotEnded; r <= EndForfeit; r++ {
+		if r.String() == s {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown end reason %q", s)
+}
+
+func parseContact(s string) (Contact, error) {
+	for c := ContactAllowed; c <= ContactForbidden; c++ {
+		if c.String() == s {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown contact %q", s)
+}
+
+func parseDoubles(s string) (Doubles, error) {
+	for d := DoublesNoBonus; d <= DoublesAnywhere; d++ {
+		if d.String() == s {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown doubles %q", s)
+}
+
+func parseTimeout(s string) (Timeout, error) {
+	for t := TimeoutPass; t <= TimeoutForfeit; t++ {
+		if t.String() == s {
+			return t, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown timeout %q", s)
+}
+
+func parseMoveKind(s string) (MoveKind, error) {
+	for k := MoveRoll; k <= MoveTimeout; k++ {
+		if k.String() == s {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown move %q", s)
+}
@@ -0,0 +1,247 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// playedGame returns a game after both players opened and player one rolled again.
+func playedGame(t *testing.T, config game.Config) game.Game {
+	t.Helper()
+
+	g, err := game.NewWithConfig("session-1", config, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 2, 0, 0, 3, 3}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, true); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerTwo", game.Coordinate{X: 12, Y: 16}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+
+	return g
+}
+
+func TestGame_JSON_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		config game.Config
+		strip  bool
+	}{
+		{
+			name:   "with history",
+			config: game.Config{},
+		},
+		{
+			name:   "without history",
+			config: game.Config{},
+			strip:  true,
+		},
+		{
+			name:   "with config",
+			config: game.Config{PassLimit: 5, Scorer: game.RowBonusScorer{Bonus: 3}},
+		},
+		{
+			name:   "with dice and bounds",
+			config: game.Config{Dice: game.Faces{1, 1, 2, 3, 4, 6}, Bounds: game.Bounds{MinWidth: 4, MaxWidth: 40, MinHeight: 4, MaxHeight: 40}},
+		},
+		{
+			name:   "with ruleset",
+			config: game.Config{Ruleset: game.Rules{CornerTouch: true, Opponents: game.ContactForbidden, OverlapOwn: true}},
+		},
+		{
+			name:   "with protected territory",
+			config: game.Config{Ruleset: game.Rules{CornerTouch: true, Opponents: game.ContactRequired, ProtectTerritory: true}},
+		},
+		{
+			name:   "with territory scoring",
+			config: game.Config{Scorer: game.TerritoryScorer{}},
+		},
+		{
+			name:   "with time control",
+			config: game.Config{Time: game.TimeControl{Base: time.Hour, Increment: time.Second, PerMove: time.Minute, OnTimeout: game.TimeoutForfeit}},
+		},
+		{
+			name:   "with doubles bonus",
+			config: game.Config{Doubles: game.DoublesAnywhere},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := playedGame(t, tt.config)
+			if tt.strip {
+				want.History = nil
+			}
+
+			data, err := json.Marshal(want)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			var got game.Game
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			assertSameState(t, got, want)
+			if got.Session != want.Session || !reflect.DeepEqual(got.Config, want.Config) {
+				t.Errorf("Unmarshal() got session %q config %v, want %q %v", got.Session, got.Config, want.Session, want.Config)
+			}
+		})
+	}
+}
+
+func TestGame_JSON_UndoAfterLoad(t *testing.T) {
+	g := playedGame(t, game.Config{})
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got game.Game
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if err := got.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Undo() error = %v", err)
+	}
+	assertSameState(t, got, g)
+}
+
+func TestGame_UnmarshalJSON_Rejects(t *testing.T) {
+	valid, err := json.Marshal(playedGame(t, game.Config{}))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr error
+	}{
+		{
+			name:    "unknown version",
+			data:    strings.Replace(string(valid), `"version":1`, `"version":99`, 1),
+			wantErr: game.ErrUnsupportedVersion,
+		},
+		{
+			name:    "overlapping pieces",
+			data:    strings.Replace(string(valid), `"x":12,"y":16`, `"x":1,"y":1`, 1),
+			wantErr: game.ErrInconsistentGame,
+		},
+		{
+			name:    "score that does not match the pieces",
+			data:    strings.Replace(string(valid), `"score":6`, `"score":60`, 1),
+			wantErr: game.ErrInconsistentGame,
+		},
+		{
+			name:    "history that does not match the pieces",
+			data:    strings.Replace(string(valid), `"roll":{"width":4,"height":4}`, `"roll":{"width":3,"height":4}`, 1),
+			wantErr: game.ErrInconsistentGame,
+		},
+		{
+			name:    "current player that does not exist",
+			data:    strings.Replace(string(valid), `"current":0`, `"current":2`, 1),
+			wantErr: game.ErrInconsistentGame,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.data == string(valid) {
+				t.Fatalf("test data was not changed")
+			}
+
+			var got game.Game
+			if err := json.Unmarshal([]byte(tt.data), &got); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Unmarshal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGame_UnmarshalJSON_RollFaces(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Dice: game.Faces{1, 1, 2, 3, 4, 6}, Seed: 42}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	roll, err := g.Roll("playerOne")
+	if err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	valid, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	// The history and the outstanding roll agree, but the dice have no five.
+	rolled := fmt.Sprintf(`"roll":{"width":%d,"height":%d}`, roll.Width, roll.Height)
+	data := strings.ReplaceAll(string(valid), rolled, `"roll":{"width":5,"height":5}`)
+	if data == string(valid) {
+		t.Fatalf("test data was not changed")
+	}
+
+	var got game.Game
+	if err := json.Unmarshal([]byte(data), &got); !errors.Is(err, game.ErrInconsistentGame) {
+		t.Errorf("Unmarshal() error = %v, wantErr %v", err, game.ErrInconsistentGame)
+	}
+}
+
+type customScorer struct{}
+
+func (customScorer) Score(*game.Board, string) uint {
+	return 0
+}
+
+func TestGame_MarshalJSON_CustomScorer(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Scorer: customScorer{}}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	if _, err := json.Marshal(g); err == nil {
+		t.Errorf("Marshal() of a custom scorer did not fail")
+	}
+}
+
+func TestGame_MarshalJSON_CustomRuleset(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Ruleset: everywhere{}}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+
+	if _, err := json.Marshal(g); err == nil {
+		t.Errorf("Marshal() of a custom ruleset did not fail")
+	}
+	if err := game.WriteRecord(&bytes.Buffer{}, &g); err == nil {
+		t.Errorf("WriteRecord() of a custom ruleset did not fail")
+	}
+}
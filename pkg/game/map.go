@@ -0,0 +1,337 @@
+package game
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// A map is drawn as rows of cells, one line per row from the top:
+//
+//	; the donut
+//	1..........
+//	....###....
+//	....###....
+//	..........2
+//
+// A . is a cell that can be played on, a # is blocked, and the digits 1 to 4 are the starting corners of the players,
+// in the order they join. Without any digits players start in the corners of the board like they do on a plain board.
+// Rows shorter than the longest one are blocked at the end, so maps don't have to be rectangles. Lines starting with ;
+// are comments.
+
+const (
+	mapOpen    = '.'
+	mapBlocked = '#'
+	mapComment = ';'
+)
+
+// ErrBadMap is returned when a map can't be read or doesn't fit a board.
+var ErrBadMap = errors.New("malformed map")
+
+// Map is the shape of a board: which cells of its Width x Height rectangle can be played on, and where players start.
+// Maps are not changed once they're made, so boards and games share them.
+type Map struct {
+	Name   string
+	Width  uint8
+	Height uint8
+
+	// blocked holds whether each cell is blocked, row by row.
+	blocked []bool
+	// starts holds the starting corners of the players, if the map has its own.
+	starts []Coordinate
+}
+
+// ParseMap reads a map in the text format.
+func ParseMap(name string, text string) (*Map, error) {
+	var rows []string
+	scanner := bufio.NewScanner(strings.NewReader(text))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || line[0] == mapComment {
+			continue
+		}
+		rows = append(rows, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("game.ParseMap(): %w", err)
+	}
+
+	return newMap(name, rows)
+}
+
+func newMap(name string, rows []string) (*Map, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %q has no rows", ErrBadMap, name)
+	}
+
+	width := 0
+	for _, row := range rows {
+		width = maxInt(width, len(row))
+	}
+	if width > math.MaxUint8 || len(rows) > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: %q is %dx%d, larger than any board can be", ErrBadMap, name, width, len(rows))
+	}
+
+	m := &Map{Name: name, Width: uint8(width), Height: uint8(len(rows)), blocked: make([]bool, width*len(rows))}
+	starts, err := m.readCells(rows)
+	if err != nil {
+		return nil, err
+	}
+	for n := 1; n <= len(starts); n++ {
+		start, ok := starts[n]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q has %d starts, but not start %d", ErrBadMap, name, len(starts), n)
+		}
+		m.starts = append(m.starts, start)
+	}
+
+	return m, nil
+}
+
+// readCells marks the blocked cells of the map from its rows and returns the starts by number. Rows shorter than the
+// map are blocked past their end.
+func (m *Map) readCells(rows []string) (map[int]Coordinate, error) {
+	width := int(m.Width)
+	starts := make(map[int]Coordinate)
+	open := 0
+	for y, row := range rows {
+		for x := 0; x < width; x++ {
+			cell := byte(mapBlocked)
+			if x < len(row) {
+				cell = row[x]
+			}
+
+			switch {
+			case cell == mapBlocked:
+				m.blocked[y*width+x] = true
+			case cell == mapOpen:
+				open++
+			case cell >= '1' && cell <= '0'+maxPlayers:
+				n := int(cell - '0')
+				if _, ok := starts[n]; ok {
+					return nil, fmt.Errorf("%w: %q has start %d twice", ErrBadMap, m.Name, n)
+				}
+				starts[n] = Coordinate{X: uint8(x + 1), Y: uint8(y + 1)}
+				open++
+			default:
+				return nil, fmt.Errorf("%w: %q has %q at %s", ErrBadMap, m.Name, cell, formatCell(Coordinate{X: uint8(x + 1), Y: uint8(y + 1)}))
+			}
+		}
+	}
+
+	if open == 0 {
+		return nil, fmt.Errorf("%w: %q has no open cells", ErrBadMap, m.Name)
+	}
+	return starts, nil
+}
+
+// Blocked checks whether a cell of the map can't be played on. Cells off the map are blocked. A map put together
+// outside of ParseMap has no cells blocked, only its size.
+func (m *Map) Blocked(c Coordinate) bool {
+	if c.X < 1 || c.X > m.Width || c.Y < 1 || c.Y > m.Height {
+		return true
+	}
+	i := int(c.Y-1)*int(m.Width) + int(c.X-1)
+	return i < len(m.blocked) && m.blocked[i]
+}
+
+// Starts returns the starting corners the map sets for its players, in the order they join. Nil if players start in
+// the corners of the board.
+func (m *Map) Starts() []Coordinate {
+	return append([]Coordinate(nil), m.starts...)
+}
+
+// Rows returns the map in the text format, one string per row.
+func (m *Map) Rows() []string {
+	starts := make(map[Coordinate]int)
+	for i, c := range m.starts {
+		starts[c] = i + 1
+	}
+
+	rows := make([]string, 0, m.Height)
+	for y := 1; y <= int(m.Height); y++ {
+		var row []byte
+		for x := 1; x <= int(m.Width); x++ {
+			c := Coordinate{X: uint8(x), Y: uint8(y)}
+			switch {
+			case starts[c] != 0:
+				row = append(row, byte('0'+starts[c]))
+			case m.Blocked(c):
+				row = append(row, mapBlocked)
+			default:
+				row = append(row, mapOpen)
+			}
+		}
+		rows = append(rows, string(row))
+	}
+	return rows
+}
+
+// String returns the map in the text format.
+func (m *Map) String() string {
+	return strings.Join(m.Rows(), "\n") + "\n"
+}
+
+type mapJSON struct {
+	Name string   `json:"name"`
+	Rows []string `json:"rows"`
+}
+
+// MarshalJSON encodes the map as its name and rows in the text format.
+func (m *Map) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mapJSON{Name: m.Name, Rows: m.Rows()})
+}
+
+// UnmarshalJSON decodes a map from its name and rows in the text format.
+func (m *Map) UnmarshalJSON(data []byte) error {
+	var mj mapJSON
+	if err := json.Unmarshal(data, &mj); err != nil {
+		return fmt.Errorf("game.Map.UnmarshalJSON(): %w", err)
+	}
+
+	decoded, err := newMap(mj.Name, mj.Rows)
+	if err != nil {
+		return fmt.Errorf("game.Map.UnmarshalJSON(): %w", err)
+	}
+
+	*m = *decoded
+	return nil
+}
+
+// NewMapBoard returns a new board in the shape of the map for 2 to 4 players. Players start where the map says, or in
+// the corners like on NewBoard, which then have to be open. The map has to be within DefaultBounds.
+func NewMapBoard(m *Map, players ...string) (Board, error) {
+	return DefaultBounds.NewMapBoard(m, players...)
+}
+
+// NewMapBoard returns a new board like the package level NewMapBoard does, for a map within these bounds.
+func (bo Bounds) NewMapBoard(m *Map, players ...string) (Board, error) {
+	b, err := bo.NewBoard(m.Width, m.Height, players...)
+	if err != nil {
+		return Board{}, fmt.Errorf("game: NewMapBoard(): %w", err)
+	}
+	b.Map = m
+
+	if len(m.starts) > 0 {
+		if len(m.starts) < len(players) {
+			return Board{}, fmt.Errorf("game: NewMapBoard(): %w: %q has starts for %d players, not %d", ErrBadMap, m.Name, len(m.starts), len(players))
+		}
+		b.Corners = append([]Coordinate(nil), m.starts[:len(players)]...)
+	}
+
+	for i, c := range b.Corners {
+		if m.Blocked(c) {
+			return Board{}, fmt.Errorf("game: NewMapBoard(): %w: starting corner of player %d at %s is blocked on %q", ErrBadMap, i+1, formatCell(c), m.Name)
+		}
+	}
+
+	return b, nil
+}
+
+// builtinMaps are the maps that come with the game, by name.
+var builtinMaps = map[string]string{
+	"donut": `
+1...............
+................
+................
+................
+................
+................
+......####......
+.....######.....
+.....######.....
+......####......
+................
+................
+................
+................
+................
+...............2
+`,
+	"cross": `
+######1.....######
+######......######
+######......######
+######......######
+######......######
+######......######
+4................3
+..................
+..................
+..................
+..................
+..................
+######......######
+######......######
+######......######
+######......######
+######......######
+######.....2######
+`,
+	"pillars": `
+1..................
+...................
+...##.....##.....##
+...##.....##.....##
+...................
+...................
+........##.........
+........##.........
+...................
+...................
+...##.....##.....##
+...##.....##.....##
+...................
+...................
+........##.........
+........##.........
+...................
+...................
+...##.....##.....##
+..................2
+`,
+	"hourglass": `
+1............3
+..............
+#............#
+##..........##
+###........###
+####......####
+#####....#####
+#####....#####
+####......####
+###........###
+##..........##
+#............#
+..............
+4............2
+`,
+}
+
+// BuiltinMap returns the map that comes with the game by its name.
+func BuiltinMap(name string) (*Map, bool) {
+	text, ok := builtinMaps[name]
+	if !ok {
+		return nil, false
+	}
+
+	m, err := ParseMap(name, text)
+	if err != nil {
+		panic(fmt.Sprintf("game: built in map %q is broken: %v", name, err))
+	}
+	return m, true
+}
+
+// BuiltinMaps returns the names of the maps that come with the game, in alphabetical order.
+func BuiltinMaps() []string {
+	names := make([]string, 0, len(builtinMaps))
+	for name := range builtinMaps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
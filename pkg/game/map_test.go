@@ -0,0 +1,406 @@
+package game_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// mustParseMap parses a map that is 8 wide and 12 high, the smallest a board can be, with a blocked square in the
+// middle.
+func mustParseMap(t *testing.T) *game.Map {
+	t.Helper()
+
+	m, err := game.ParseMap("test", `
+; a small map
+1.......
+........
+........
+........
+........
+...##...
+...##...
+........
+........
+........
+........
+.......2
+`)
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+	return m
+}
+
+func TestParseMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		wantWidth  uint8
+		wantHeight uint8
+		wantStarts []game.Coordinate
+		wantErr    bool
+	}{
+		{
+			name:       "rectangle with starts",
+			text:       "1..\n.#.\n..2\n",
+			wantWidth:  3,
+			wantHeight: 3,
+			wantStarts: []game.Coordinate{{X: 1, Y: 1}, {X: 3, Y: 3}},
+		},
+		{
+			name:       "short rows are blocked at the end",
+			text:       "....\n..\n....\n",
+			wantWidth:  4,
+			wantHeight: 3,
+		},
+		{
+			name:    "unknown cell",
+			text:    "..x.\n",
+			wantErr: true,
+		},
+		{
+			name:    "start twice",
+			text:    "1..1\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing start",
+			text:    "1..3\n",
+			wantErr: true,
+		},
+		{
+			name:    "nothing open",
+			text:    "###\n",
+			wantErr: true,
+		},
+		{
+			name:    "no rows",
+			text:    "; just a comment\n",
+			wantErr: true,
+		},
+		{
+			name:    "wider than any board",
+			text:    strings.Repeat(".", 256),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := game.ParseMap("test", tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMap() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if !errors.Is(err, game.ErrBadMap) {
+					t.Errorf("ParseMap() error = %v, want %v", err, game.ErrBadMap)
+				}
+				return
+			}
+
+			if m.Width != tt.wantWidth || m.Height != tt.wantHeight {
+				t.Errorf("ParseMap() size = %dx%d, want %dx%d", m.Width, m.Height, tt.wantWidth, tt.wantHeight)
+			}
+			if !reflect.DeepEqual(m.Starts(), tt.wantStarts) {
+				t.Errorf("ParseMap() starts = %v, want %v", m.Starts(), tt.wantStarts)
+			}
+		})
+	}
+}
+
+func TestMap_Blocked(t *testing.T) {
+	m, err := game.ParseMap("test", "1..\n.#.\n..\n")
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		c    game.Coordinate
+		want bool
+	}{
+		{name: "open", c: game.Coordinate{X: 2, Y: 1}, want: false},
+		{name: "start", c: game.Coordinate{X: 1, Y: 1}, want: false},
+		{name: "blocked", c: game.Coordinate{X: 2, Y: 2}, want: true},
+		{name: "end of a short row", c: game.Coordinate{X: 3, Y: 3}, want: true},
+		{name: "off the map", c: game.Coordinate{X: 4, Y: 1}, want: true},
+		{name: "zero", c: game.Coordinate{X: 0, Y: 0}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Blocked(tt.c); got != tt.want {
+				t.Errorf("Blocked() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMap_Blocked_Literal(t *testing.T) {
+	m := &game.Map{Name: "literal", Width: 12, Height: 16}
+	b := game.Board{Width: 12, Height: 16, Map: m}
+
+	tests := []struct {
+		name string
+		c    game.Coordinate
+		want bool
+	}{
+		{name: "top left", c: game.Coordinate{X: 1, Y: 1}, want: true},
+		{name: "bottom right", c: game.Coordinate{X: 12, Y: 16}, want: true},
+		{name: "off the map", c: game.Coordinate{X: 13, Y: 1}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.IsCoordinateWithin(tt.c); got != tt.want {
+				t.Errorf("IsCoordinateWithin() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMap_Rows_Largest(t *testing.T) {
+	row := strings.Repeat(".", math.MaxUint8)
+	rows := make([]string, math.MaxUint8)
+	for i := range rows {
+		rows[i] = row
+	}
+	rows[0] = "1" + row[1:]
+	rows[len(rows)-1] = row[1:] + "2"
+
+	m, err := game.ParseMap("largest", strings.Join(rows, "\n"))
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+	if got := m.Rows(); !reflect.DeepEqual(got, rows) {
+		t.Errorf("Rows() got %d rows, want %d", len(got), len(rows))
+	}
+}
+
+func TestMap_RoundTrip(t *testing.T) {
+	want := mustParseMap(t)
+
+	got, err := game.ParseMap(want.Name, want.String())
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseMap() of String() = %v, want %v", got, want)
+	}
+
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var decoded game.Map
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if !reflect.DeepEqual(&decoded, want) {
+		t.Errorf("Unmarshal() of Marshal() = %v, want %v", decoded, *want)
+	}
+
+	if err := json.Unmarshal([]byte(`{"name": "bad", "rows": ["..?"]}`), &decoded); !errors.Is(err, game.ErrBadMap) {
+		t.Errorf("Unmarshal() error = %v, want %v", err, game.ErrBadMap)
+	}
+}
+
+func TestBuiltinMap(t *testing.T) {
+	names := game.BuiltinMaps()
+	if len(names) == 0 {
+		t.Fatalf("BuiltinMaps() is empty")
+	}
+
+	for _, name := range names {
+		t.Run(name, func(t *testing.T) {
+			m, ok := game.BuiltinMap(name)
+			if !ok {
+				t.Fatalf("BuiltinMap() did not find %q", name)
+			}
+
+			players := []string{"a", "b", "c", "d"}
+			if n := len(m.Starts()); n >= 2 {
+				players = players[:n]
+			}
+			b, err := game.NewMapBoard(m, players...)
+			if err != nil {
+				t.Fatalf("NewMapBoard() error = %v", err)
+			}
+			if b.FreeCells() >= uint(b.Width)*uint(b.Height) {
+				t.Errorf("FreeCells() = %d, want some cells blocked on %dx%d", b.FreeCells(), b.Width, b.Height)
+			}
+			for _, p := range b.Players {
+				if len(b.LegalPlacements(p.ID, 1, 1)) != 1 {
+					t.Errorf("LegalPlacements() of player %q does not have their starting corner", p.ID)
+				}
+			}
+		})
+	}
+
+	if _, ok := game.BuiltinMap("nowhere"); ok {
+		t.Errorf("BuiltinMap() found a map that does not exist")
+	}
+}
+
+func TestNewMapBoard(t *testing.T) {
+	tests := []struct {
+		name        string
+		text        string
+		players     []string
+		wantCorners []game.Coordinate
+		wantErr     bool
+	}{
+		{
+			name:        "starts of the map",
+			text:        "..1.....\n" + strings.Repeat("........\n", 10) + ".....2..\n",
+			players:     []string{"a", "b"},
+			wantCorners: []game.Coordinate{{X: 3, Y: 1}, {X: 6, Y: 12}},
+		},
+		{
+			name:        "corners of the board",
+			text:        strings.Repeat("........\n", 12),
+			players:     []string{"a", "b", "c"},
+			wantCorners: []game.Coordinate{{X: 1, Y: 1}, {X: 8, Y: 12}, {X: 8, Y: 1}},
+		},
+		{
+			name:    "blocked corner",
+			text:    "#.......\n" + strings.Repeat("........\n", 11),
+			players: []string{"a", "b"},
+			wantErr: true,
+		},
+		{
+			name:    "not enough starts",
+			text:    "..1.....\n" + strings.Repeat("........\n", 10) + ".....2..\n",
+			players: []string{"a", "b", "c"},
+			wantErr: true,
+		},
+		{
+			name:    "smaller than the smallest board",
+			text:    "1..\n..2\n",
+			players: []string{"a", "b"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := game.ParseMap("test", tt.text)
+			if err != nil {
+				t.Fatalf("ParseMap() error = %v", err)
+			}
+
+			b, err := game.NewMapBoard(m, tt.players...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewMapBoard() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(b.Corners, tt.wantCorners) {
+				t.Errorf("NewMapBoard() corners = %v, want %v", b.Corners, tt.wantCorners)
+			}
+		})
+	}
+}
+
+func TestBoard_Map(t *testing.T) {
+	b, err := game.NewMapBoard(mustParseMap(t), "id-player-1", "id-player-2")
+	if err != nil {
+		t.Fatalf("NewMapBoard() error = %v", err)
+	}
+
+	if b.IsCoordinateWithin(game.Coordinate{X: 4, Y: 6}) {
+		t.Errorf("IsCoordinateWithin() of a blocked cell = true")
+	}
+	if !b.IsCoordinateWithin(game.Coordinate{X: 3, Y: 6}) {
+		t.Errorf("IsCoordinateWithin() of an open cell = false")
+	}
+	if got := b.FreeCells(); got != 8*12-4 {
+		t.Errorf("FreeCells() = %d, want %d", got, 8*12-4)
+	}
+
+	if _, err := b.PlacePiece(mustNewPiece(t, "id-player-1", 1, 1, 3, 5)); err != nil {
+		t.Fatalf("PlacePiece() error = %v", err)
+	}
+	// Covers the blocked square.
+	if _, err := b.PlacePiece(mustNewPiece(t, "id-player-1", 1, 6, 4, 1)); err == nil {
+		t.Errorf("PlacePiece() placed a piece on a blocked cell")
+	}
+	for _, pl := range b.LegalPlacements("id-player-1", 4, 1) {
+		p, err := pl.Piece("id-player-1")
+		if err != nil {
+			t.Fatalf("Piece() error = %v", err)
+		}
+		for _, c := range p.Coordinates {
+			if !b.IsCoordinateWithin(c) {
+				t.Errorf("LegalPlacements() has %v, which covers blocked %v", pl, c)
+			}
+		}
+	}
+}
+
+func TestGame_Map_RoundTrip(t *testing.T) {
+	donut, ok := game.BuiltinMap("donut")
+	if !ok {
+		t.Fatalf("BuiltinMap() did not find the donut")
+	}
+
+	tests := []struct {
+		name     string
+		m        *game.Map
+		wantRows bool
+	}{
+		{name: "built in map", m: donut, wantRows: false},
+		{name: "custom map", m: mustParseMap(t), wantRows: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want, err := game.NewWithConfig("1", game.Config{Map: tt.m}, 0, 0, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewWithConfig() error = %v", err)
+			}
+			if _, err := want.Roll("playerOne"); err != nil {
+				t.Fatalf("Roll() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			if err := game.WriteRecord(&buf, &want); err != nil {
+				t.Fatalf("WriteRecord() error = %v", err)
+			}
+			if got := strings.Contains(buf.String(), "[MapRows "); got != tt.wantRows {
+				t.Errorf("WriteRecord() wrote map rows = %t, want %t\n%s", got, tt.wantRows, buf.String())
+			}
+			fromRecord, err := game.ReadRecord(&buf)
+			if err != nil {
+				t.Fatalf("ReadRecord() error = %v", err)
+			}
+
+			data, err := json.Marshal(want)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var fromJSON game.Game
+			if err := json.Unmarshal(data, &fromJSON); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			for _, got := range []game.Game{fromRecord, fromJSON} {
+				assertSameState(t, got, want)
+				if !reflect.DeepEqual(got.Board.Map, want.Board.Map) || !reflect.DeepEqual(got.Config.Map, tt.m) {
+					t.Errorf("decoded map = %v, want %v", got.Board.Map, tt.m)
+				}
+			}
+		})
+	}
+}
+
+func TestNewWithConfig_MapSize(t *testing.T) {
+	m := mustParseMap(t)
+
+	if _, err := game.NewWithConfig("1", game.Config{Map: m}, 8, 12, "a", "b"); err != nil {
+		t.Errorf("NewWithConfig() with the size of the map error = %v", err)
+	}
+	if _, err := game.NewWithConfig("1", game.Config{Map: m}, 12, 16, "a", "b"); err == nil {
+		t.Errorf("NewWithConfig() with a size other than the map's did not fail")
+	}
+}
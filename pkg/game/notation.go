@@ -0,0 +1,442 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A game record is a header of tags followed by the numbered turns of the game, one per line:
+//
+//	[Session "1"]
+//	[Board "12x16"]
+//	[Player1 "playerOne"]
+//	[Player2 "playerTwo"]
+//	[Seed "42"]
+//	[Ruleset "standard"]
+//
+//	1. 2x3 @A1 r
+//	2. 1x1 @L16
+//	3. 4x4 pass
+//	4. 3x1
+//
+// Every turn is the roll, then where its top left corner was placed, with an r if it was rotated, or pass. A player who
+// ran out of time has time after their roll, or instead of it if they hadn't rolled yet. Columns are letters from A,
+// rows are numbers from 1. The last turn has no placement if the player hasn't placed yet. Anything
+// after a ; up to the end of the line is a comment.
+//
+// The Ruleset tag is standard, or the variants of Rules the game is played with, eg "corner-touch overlap-own". The Map
+// tag names the map of the board. Maps that don't come with the game have their rows in a MapRows tag too, separated
+// by slashes. The Dice tag lists the faces of the dice if they aren't six sided, eg "1 1 2 3 4 6", and the Bounds tag
+// has the smallest and largest board size if they aren't the default ones, eg "4x4 40x40". The Doubles tag is the
+// bonus for rolling a double, "extra turn" or "anywhere", if there is one. The Time tag is the time control, eg
+// "base 5m0s increment 2s move 30s timeout forfeit", leaving out the parts that aren't used. Records don't keep the time
+// players have left: a game read from a record starts with a full clock. Games with fair dice have the commitment in a
+// Commitment tag and the entropy of every player in Entropy1, Entropy2 and so on, in hex. The ServerSeed tag reveals
+// the server seed once the game is over.
+
+const standardRuleset = "standard"
+
+// Rule variants in the Ruleset tag.
+const (
+	variantCornerTouch        = "corner-touch"
+	variantOpponentsRequired  = "opponents-required"
+	variantOpponentsForbidden = "opponents-forbidden"
+	variantOverlapOwn         = "overlap-own"
+	variantProtectTerritory   = "protect-territory"
+)
+
+// ErrBadRecord is returned when a game record can't be read.
+var ErrBadRecord = errors.New("malformed game record")
+
+var (
+	tagPattern  = regexp.MustCompile(`^\[(\w+)\s+("(?:[^"\\]|\\.)*")\]$`)
+	turnPattern = regexp.MustCompile(`^(\d+)\.$`)
+	sizePattern = regexp.MustCompile(`^(\d+)x(\d+)$`)
+	cellPattern = regexp.MustCompile(`^@([A-Z]+)(\d+)$`)
+)
+
+// WriteRecord writes the history of the game as a game record.
+func WriteRecord(w io.Writer, g *Game) error {
+	bw := bufio.NewWriter(w)
+
+	writeTag(bw, "Session", g.Session)
+	writeTag(bw, "Board", fmt.Sprintf("%dx%d", g.Board.Width, g.Board.Height))
+	for i, p := range g.Board.Players {
+		writeTag(bw, fmt.Sprintf("Player%d", i+1), p.ID)
+	}
+	for _, section := range headerSections {
+		if err := section.write(bw, g); err != nil {
+			return fmt.Errorf("game.WriteRecord(): %w", err)
+		}
+	}
+
+	writeTurns(bw, g.History)
+	return bw.Flush()
+}
+
+// writeTurns writes the moves of the game, one numbered turn per line.
+func writeTurns(w io.Writer, history []Move) {
+	turn, rolled := 0, false
+	for _, m := range history {
+		switch m.Kind {
+		case MoveRoll:
+			// Ends the previous turn, or the header before the first one.
+			turn++
+			fmt.Fprintf(w, "\n%d. %dx%d", turn, m.Roll.Width, m.Roll.Height)
+		case MoveTimeout:
+			if !rolled {
+				turn++
+				fmt.Fprintf(w, "\n%d.", turn)
+			}
+			fmt.Fprint(w, " time")
+		case MovePlace:
+			fmt.Fprintf(w, " @%s", formatCell(m.Origin))
+			if m.Rotated {
+				fmt.Fprint(w, " r")
+			}
+		case MovePass:
+			fmt.Fprint(w, " pass")
+		}
+		rolled = m.Kind == MoveRoll
+	}
+	if turn > 0 {
+		fmt.Fprint(w, "\n")
+	}
+}
+
+// ReadRecord reads a game record and replays it into a game. The dice of the game continue from the seed in the
+// record, if it has one.
+func ReadRecord(r io.Reader) (Game, error) {
+	tags := make(map[string]string)
+	var tokens []string
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if i := strings.IndexByte(text, ';'); i >= 0 {
+			text = text[:i]
+		}
+		text = strings.TrimSpace(text)
+
+		if strings.HasPrefix(text, "[") {
+			if len(tokens) > 0 {
+				return Game{}, fmt.Errorf("%w: line %d: tag after the moves", ErrBadRecord, line)
+			}
+			match := tagPattern.FindStringSubmatch(text)
+			if match == nil {
+				return Game{}, fmt.Errorf("%w: line %d: bad tag %q", ErrBadRecord, line, text)
+			}
+			value, err := strconv.Unquote(match[2])
+			if err != nil {
+				return Game{}, fmt.Errorf("%w: line %d: bad tag value %s", ErrBadRecord, line, match[2])
+			}
+			tags[match[1]] = value
+			continue
+		}
+
+		tokens = append(tokens, strings.Fields(text)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return Game{}, fmt.Errorf("game.ReadRecord(): %w", err)
+	}
+
+	g, err := newFromTags(tags)
+	if err != nil {
+		return Game{}, err
+	}
+
+	if err := g.replayTurns(tokens); err != nil {
+		return Game{}, err
+	}
+
+	g.resumeDice()
+	return g, nil
+}
+
+func newFromTags(tags map[string]string) (Game, error) {
+	var width, height uint8
+	if _, err := fmt.Sscanf(tags["Board"], "%dx%d", &width, &height); err != nil {
+		return Game{}, fmt.Errorf("%w: bad board size %q", ErrBadRecord, tags["Board"])
+	}
+
+	var players []string
+	for i := 1; ; i++ {
+		p, ok := tags[fmt.Sprintf("Player%d", i)]
+		if !ok {
+			break
+		}
+		players = append(players, p)
+	}
+
+	var config Config
+	for _, section := range headerSections {
+		if err := section.read(tags, &config); err != nil {
+			return Game{}, fmt.Errorf("%w: %v", ErrBadRecord, err)
+		}
+	}
+
+	g, err := NewWithConfig(tags["Session"], config, width, height, players...)
+	if err != nil {
+		return Game{}, fmt.Errorf("%w: %v", ErrBadRecord, err)
+	}
+	if err := g.readFairness(tags); err != nil {
+		return Game{}, fmt.Errorf("%w: %v", ErrBadRecord, err)
+	}
+	return g, nil
+}
+
+// replayTurns applies the turns of a game record to the game.
+func (g *Game) replayTurns(tokens []string) error {
+	for turn := 1; len(tokens) > 0; turn++ {
+		match := turnPattern.FindStringSubmatch(tokens[0])
+		if match == nil || match[1] != strconv.Itoa(turn) {
+			return fmt.Errorf("%w: expected turn %d, got %q", ErrBadRecord, turn, tokens[0])
+		}
+		if len(tokens) < 2 {
+			return fmt.Errorf("%w: turn %d has no roll", ErrBadRecord, turn)
+		}
+
+		var err error
+		if tokens, err = g.replayTurn(tokens[1:]); err != nil {
+			return fmt.Errorf("%w: turn %d: %v", ErrBadRecord, turn, err)
+		}
+	}
+
+	return nil
+}
+
+// replayTurn applies a turn of the current player from its roll on, and returns the tokens after it.
+func (g *Game) replayTurn(tokens []string) ([]string, error) {
+	player := g.CurrentPlayer().ID
+	if tokens[0] == "time" {
+		return tokens[1:], g.apply(Move{Kind: MoveTimeout, Player: player})
+	}
+
+	roll, err := parseRoll(tokens[0], g.Board.dice())
+	if err != nil {
+		return nil, err
+	}
+	if err := g.apply(Move{Kind: MoveRoll, Player: player, Roll: roll}); err != nil {
+		return nil, err
+	}
+	stuck := len(g.Board.LegalPlacements(player, roll.Width, roll.Height)) == 0
+
+	m, tokens, err := parseMove(tokens[1:], player, stuck)
+	switch {
+	case err != nil:
+		return nil, err
+	case m != nil:
+		return tokens, g.apply(*m)
+	case stuck:
+		return nil, fmt.Errorf("%dx%d can't be placed, but was not passed", roll.Width, roll.Height)
+	default:
+		return tokens, nil
+	}
+}
+
+// parseMove reads what the player did with their roll, and returns the tokens after it. The move is nil if the
+// tokens don't start with one, because the player hasn't placed yet or the next turn follows.
+func parseMove(tokens []string, player string, stuck bool) (*Move, []string, error) {
+	switch {
+	case len(tokens) == 0:
+		return nil, tokens, nil
+	case tokens[0] == "pass":
+		return &Move{Kind: MovePass, Player: player, Forced: stuck}, tokens[1:], nil
+	case tokens[0] == "time":
+		return &Move{Kind: MoveTimeout, Player: player}, tokens[1:], nil
+	case cellPattern.MatchString(tokens[0]):
+		origin, err := parseCell(tokens[0])
+		if err != nil {
+			return nil, nil, err
+		}
+		m := &Move{Kind: MovePlace, Player: player, Origin: origin}
+		tokens = tokens[1:]
+		if len(tokens) > 0 && tokens[0] == "r" {
+			m.Rotated = true
+			tokens = tokens[1:]
+		}
+		return m, tokens, nil
+	default:
+		return nil, tokens, nil
+	}
+}
+
+func writeTag(w io.Writer, name string, value string) {
+	fmt.Fprintf(w, "[%s %s]\n", name, strconv.Quote(value))
+}
+
+func formatRuleset(r Ruleset) (string, error) {
+	switch r := r.(type) {
+	case nil:
+		return standardRuleset, nil
+	case Rules:
+		var variants []string
+		if r.CornerTouch {
+			variants = append(variants, variantCornerTouch)
+		}
+		switch r.Opponents {
+		case ContactRequired:
+			variants = append(variants, variantOpponentsRequired)
+		case ContactForbidden:
+			variants = append(variants, variantOpponentsForbidden)
+		}
+		if r.OverlapOwn {
+			variants = append(variants, variantOverlapOwn)
+		}
+		if r.ProtectTerritory {
+			variants = append(variants, variantProtectTerritory)
+		}
+		if len(variants) == 0 {
+			return standardRuleset, nil
+		}
+		return strings.Join(variants, " "), nil
+	default:
+		return "", fmt.Errorf("can't write ruleset %T", r)
+	}
+}
+
+func parseRuleset(s string) (Ruleset, error) {
+	if s == standardRuleset {
+		return nil, nil
+	}
+
+	var r Rules
+	fields := strings.Fields(s)
+	for _, variant := range fields {
+		switch variant {
+		case variantCornerTouch:
+			r.CornerTouch = true
+		case variantOpponentsRequired:
+			r.Opponents = ContactRequired
+		case variantOpponentsForbidden:
+			r.Opponents = ContactForbidden
+		case variantOverlapOwn:
+			r.OverlapOwn = true
+		case variantProtectTerritory:
+			r.ProtectTerritory = true
+		default:
+			return nil, fmt.Errorf("unknown ruleset %q", s)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("unknown ruleset %q", s)
+	}
+	return r, nil
+}
+
+func formatTimeControl(tc TimeControl) string {
+	var parts []string
+	if tc.Base > 0 {
+		parts = append(parts, "base", tc.Base.String())
+	}
+	if tc.Increment > 0 {
+		parts = append(parts, "increment", tc.Increment.String())
+	}
+	if tc.PerMove > 0 {
+		parts = append(parts, "move", tc.PerMove.String())
+	}
+	return strings.Join(append(parts, "timeout", tc.OnTimeout.String()), " ")
+}
+
+func parseTimeControl(s string) (TimeControl, error) {
+	var tc TimeControl
+	fields := strings.Fields(s)
+	if len(fields)%2 != 0 {
+		return TimeControl{}, fmt.Errorf("bad time control %q", s)
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		if fields[i] == "timeout" {
+			t, err := parseTimeout(fields[i+1])
+			if err != nil {
+				return TimeControl{}, err
+			}
+			tc.OnTimeout = t
+			continue
+		}
+
+		d, err := time.ParseDuration(fields[i+1])
+		if err != nil {
+			return TimeControl{}, fmt.Errorf("bad time control %q: %v", s, err)
+		}
+		switch fields[i] {
+		case "base":
+			tc.Base = d
+		case "increment":
+			tc.Increment = d
+		case "move":
+			tc.PerMove = d
+		default:
+			return TimeControl{}, fmt.Errorf("bad time control %q", s)
+		}
+	}
+
+	if !tc.enabled() {
+		return TimeControl{}, fmt.Errorf("time control %q has no limit", s)
+	}
+	return tc, nil
+}
+
+func formatBonus(bonus uint) string {
+	if bonus == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(bonus), 10)
+}
+
+// formatCell writes a coordinate as its column letters and row number, so {X: 1, Y: 1} is A1 and {X: 27, Y: 3} is AA3.
+func formatCell(c Coordinate) string {
+	return ColumnName(int(c.X)) + strconv.Itoa(int(c.Y))
+}
+
+// ColumnName returns the letters of the column at x, A for 1, Z for 26, AA for 27 and so on.
+func ColumnName(x int) string {
+	var name []byte
+	for ; x > 0; x = (x - 1) / 26 {
+		name = append([]byte{byte('A' + (x-1)%26)}, name...)
+	}
+	return string(name)
+}
+
+func parseCell(s string) (Coordinate, error) {
+	match := cellPattern.FindStringSubmatch(s)
+	if match == nil {
+		return Coordinate{}, fmt.Errorf("bad cell %q", s)
+	}
+
+	x := 0
+	for _, r := range match[1] {
+		x = x*26 + int(r-'A') + 1
+	}
+	y, err := strconv.Atoi(match[2])
+	if err != nil || x > 255 || y > 255 {
+		return Coordinate{}, fmt.Errorf("bad cell %q", s)
+	}
+
+	return Coordinate{X: uint8(x), Y: uint8(y)}, nil
+}
+
+// parseRoll reads a roll, which has to be something the dice can roll.
+func parseRoll(s string, faces Faces) (Roll, error) {
+	match := sizePattern.FindStringSubmatch(s)
+	if match == nil {
+		return Roll{}, fmt.Errorf("bad roll %q", s)
+	}
+
+	width, err := strconv.ParseUint(match[1], 10, 8)
+	if err != nil || !faces.Has(uint8(width)) {
+		return Roll{}, fmt.Errorf("bad roll %q", s)
+	}
+	height, err := strconv.ParseUint(match[2], 10, 8)
+	if err != nil || !faces.Has(uint8(height)) {
+		return Roll{}, fmt.Errorf("bad roll %q", s)
+	}
+
+	return Roll{Width: uint8(width), Height: uint8(height)}, nil
+}
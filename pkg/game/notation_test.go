@@ -0,0 +1,286 @@
+package game_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestWriteRecord(t *testing.T) {
+	g := playedGame(t, game.Config{Seed: 42, Scorer: game.RowBonusScorer{Bonus: 3}})
+
+	var buf bytes.Buffer
+	if err := game.WriteRecord(&buf, &g); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+
+	want := `[Session "session-1"]
+[Board "12x16"]
+[Player1 "playerOne"]
+[Player2 "playerTwo"]
+[Seed "42"]
+[Ruleset "standard"]
+[Scorer "rows 3"]
+
+1. 2x3 @A1 r
+2. 1x1 @L16
+3. 4x4
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteRecord() got = \n%s, want \n%s", got, want)
+	}
+}
+
+// timedOutGame returns a game where both players ran out of time, which ended it.
+func timedOutGame(t *testing.T) game.Game {
+	t.Helper()
+
+	g, clock := newTimedGame(t, game.TimeControl{Base: time.Minute, PerMove: 10 * time.Second})
+	// Player one runs out before rolling, player two after.
+	clock.advance(time.Minute)
+	if !g.Tick() {
+		t.Fatalf("Tick() did not time out player one")
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	clock.advance(10 * time.Second)
+	// Two passes in a row end the game.
+	if !g.Tick() || g.Ended != game.EndPassLimit {
+		t.Fatalf("Tick() did not time out player two and end the game, ended %v", g.Ended)
+	}
+	return g
+}
+
+// forcedPassGame returns a game whose last roll could not be placed anywhere.
+func forcedPassGame(t *testing.T) game.Game {
+	t.Helper()
+
+	g, err := game.New("1", 8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{5, 5, 0, 0, 5, 5, 5, 5}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Pass("playerTwo"); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 1, Y: 7}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	// Player two's opening 6x6 piece can't cover their corner any more.
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if last := g.History[len(g.History)-1]; !last.Forced {
+		t.Fatalf("Roll() did not force a pass, history %v", g.History)
+	}
+	return g
+}
+
+func TestReadRecord_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		game func(t *testing.T) game.Game
+	}{
+		{
+			name: "game in progress",
+			game: func(t *testing.T) game.Game {
+				return playedGame(t, game.Config{Seed: 42, PassLimit: 4})
+			},
+		},
+		{
+			name: "dice and bounds",
+			game: func(t *testing.T) game.Game {
+				return playedGame(t, game.Config{Dice: game.Faces{1, 1, 2, 3, 4, 6}, Bounds: game.Bounds{MinWidth: 4, MaxWidth: 40, MinHeight: 4, MaxHeight: 40}})
+			},
+		},
+		{
+			name: "rule variants",
+			game: func(t *testing.T) game.Game {
+				return playedGame(t, game.Config{Ruleset: game.Rules{CornerTouch: true, Opponents: game.ContactRequired}})
+			},
+		},
+		{
+			name: "protected territory",
+			game: func(t *testing.T) game.Game {
+				return playedGame(t, game.Config{Ruleset: game.Rules{CornerTouch: true, Opponents: game.ContactRequired, ProtectTerritory: true}, Scorer: game.TerritoryScorer{}})
+			},
+		},
+		{
+			name: "doubles bonus",
+			game: func(t *testing.T) game.Game {
+				return playedGame(t, game.Config{Doubles: game.DoublesAnywhere})
+			},
+		},
+		{
+			name: "timeouts",
+			game: timedOutGame,
+		},
+		{
+			name: "forced pass",
+			game: forcedPassGame,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.game(t)
+
+			var buf bytes.Buffer
+			if err := game.WriteRecord(&buf, &want); err != nil {
+				t.Fatalf("WriteRecord() error = %v", err)
+			}
+
+			got, err := game.ReadRecord(&buf)
+			if err != nil {
+				t.Fatalf("ReadRecord() error = %v", err)
+			}
+
+			assertSameState(t, got, want)
+			// The clock the game was timed with isn't part of the record.
+			want.Config.Clock = nil
+			if got.Session != want.Session || !reflect.DeepEqual(got.Config, want.Config) {
+				t.Errorf("ReadRecord() got session %q config %v, want %q %v", got.Session, got.Config, want.Session, want.Config)
+			}
+		})
+	}
+}
+
+func TestReadRecord_DiceContinue(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Seed: 7}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Pass("playerOne"); err != nil {
+		t.Fatalf("Pass() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := game.WriteRecord(&buf, &g); err != nil {
+		t.Fatalf("WriteRecord() error = %v", err)
+	}
+	got, err := game.ReadRecord(&buf)
+	if err != nil {
+		t.Fatalf("ReadRecord() error = %v", err)
+	}
+
+	want, err := g.Roll("playerTwo")
+	if err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if roll, err := got.Roll("playerTwo"); err != nil || roll != want {
+		t.Errorf("Roll() after ReadRecord() = %v, %v, want %v", roll, err, want)
+	}
+}
+
+func TestReadRecord_Errors(t *testing.T) {
+	header := "[Board \"12x16\"]\n[Player1 \"a\"]\n[Player2 \"b\"]\n\n"
+
+	tests := []struct {
+		name   string
+		record string
+	}{
+		{
+			name:   "bad board size",
+			record: "[Board \"big\"]\n[Player1 \"a\"]\n[Player2 \"b\"]\n",
+		},
+		{
+			name:   "unknown ruleset",
+			record: "[Ruleset \"chaos\"]\n" + header,
+		},
+		{
+			name:   "turns out of order",
+			record: header + "2. 1x1 @A1\n",
+		},
+		{
+			name:   "bad roll",
+			record: header + "1. 9x1 @A1\n",
+		},
+		{
+			name:   "roll the dice can't make",
+			record: "[Dice \"1 2 3 4\"]\n" + header + "1. 5x1 @A1\n",
+		},
+		{
+			name:   "bad dice",
+			record: "[Dice \"one\"]\n" + header,
+		},
+		{
+			name:   "unknown doubles bonus",
+			record: "[Doubles \"triples\"]\n" + header,
+		},
+		{
+			name:   "bad time control",
+			record: "[Time \"base soon\"]\n" + header,
+		},
+		{
+			name:   "time control without a limit",
+			record: "[Time \"timeout pass\"]\n" + header,
+		},
+		{
+			name:   "bad bounds",
+			record: "[Bounds \"small\"]\n" + header,
+		},
+		{
+			name:   "illegal placement",
+			record: header + "1. 1x1 @B1\n",
+		},
+		{
+			name:   "turn after a roll that was not placed",
+			record: header + "1. 1x1\n2. 1x1 @L16\n",
+		},
+		{
+			name:   "tag after the moves",
+			record: header + "1. 1x1 @A1\n[Seed \"1\"]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := game.ReadRecord(strings.NewReader(tt.record)); !errors.Is(err, game.ErrBadRecord) {
+				t.Errorf("ReadRecord() error = %v, want %v", err, game.ErrBadRecord)
+			}
+		})
+	}
+}
+
+func TestReadRecord_Comments(t *testing.T) {
+	record := `[Board "24x30"] ; the big one
+[Player1 "a"]
+[Player2 "b"]
+
+1. 1x1 @A1 ; opening
+2. 6x1 @S30
+3. 2x2 pass`
+
+	g, err := game.ReadRecord(strings.NewReader(record))
+	if err != nil {
+		t.Fatalf("ReadRecord() error = %v", err)
+	}
+
+	if len(g.Board.Pieces) != 2 || g.Board.Pieces[1].Origin != (game.Coordinate{X: 19, Y: 30}) || g.CurrentPlayer().ID != "b" {
+		t.Errorf("ReadRecord() got pieces %v, current %s", g.Board.Pieces, g.CurrentPlayer().ID)
+	}
+}
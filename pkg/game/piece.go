@@ -6,9 +6,7 @@ import (
 
 const (
 	minPieceWidth  = 0
-	maxPieceWidth  = 6
 	minPieceHeight = 0
-	maxPieceHeight = 6
 )
 
 // Piece holds info about player it belongs to, its own coordinates and dimensions.
@@ -28,12 +26,21 @@ type Coordinate struct {
 	Y uint8
 }
 
-// NewPiece returns a piece if the width and the height are within a given size constraint.
+// NewPiece returns a piece if the width and the height are within a given size constraint: no side can be longer than
+// a six sided die rolls.
 func NewPiece(player string, originX uint8, originY uint8, width uint8, height uint8) (Piece, error) {
-	if width <= minPieceWidth || width > maxPieceWidth || height <= minPieceHeight || height > maxPieceHeight {
+	return D6.NewPiece(player, originX, originY, width, height)
+}
+
+// NewPiece returns a piece if no side of it is longer than the dice roll.
+func (f Faces) NewPiece(player string, originX uint8, originY uint8, width uint8, height uint8) (Piece, error) {
+	if width <= minPieceWidth || width > f.Max() || height <= minPieceHeight || height > f.Max() {
 		return Piece{}, fmt.Errorf("can't create piece with these dimensions: width: %d, height: %d", width, height)
 	}
+	return newPiece(player, originX, originY, width, height), nil
+}
 
+func newPiece(player string, originX uint8, originY uint8, width uint8, height uint8) Piece {
 	p := Piece{
 		Player: player,
 		Origin: Coordinate{
@@ -48,7 +55,23 @@ func NewPiece(player string, originX uint8, originY uint8, width uint8, height u
 	p.Corners = p.getCorners()
 	p.Coordinates = p.getAllCoordinates()
 
-	return p, nil
+	return p
+}
+
+// Rotated returns the piece turned by 90 degrees, keeping its origin in the top left corner.
+func (p Piece) Rotated() Piece {
+	r := Piece{
+		Player: p.Player,
+		Origin: p.Origin,
+		Width:  p.Height,
+		Height: p.Width,
+	}
+
+	r.AdjacentFields = r.getAdjacentFields()
+	r.Corners = r.getCorners()
+	r.Coordinates = r.getAllCoordinates()
+
+	return r
 }
 
 func (p Piece) getCorners() []Coordinate {
@@ -424,3 +424,71 @@ func TestPiece_IsCoordinateWithin(t *testing.T) {
 		})
 	}
 }
+
+func TestPiece_Rotated(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  uint8
+		height uint8
+	}{
+		{
+			name:   "tall piece becomes wide",
+			width:  2,
+			height: 5,
+		},
+		{
+			name:   "wide piece becomes tall",
+			width:  6,
+			height: 1,
+		},
+		{
+			name:   "square piece stays the same",
+			width:  3,
+			height: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := game.NewPiece("playerid-1", 4, 6, tt.width, tt.height)
+			if err != nil {
+				t.Fatalf("NewPiece() error = %v", err)
+			}
+
+			want, err := game.NewPiece("playerid-1", 4, 6, tt.height, tt.width)
+			if err != nil {
+				t.Fatalf("NewPiece() error = %v", err)
+			}
+
+			if got := p.Rotated(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Rotated() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestFaces_NewPiece(t *testing.T) {
+	tests := []struct {
+		name    string
+		faces   game.Faces
+		width   uint8
+		height  uint8
+		wantErr bool
+	}{
+		{name: "d8 allows 8 long sides", faces: game.D8, width: 8, height: 7, wantErr: false},
+		{name: "d8 does not allow 9 long sides", faces: game.D8, width: 9, height: 1, wantErr: true},
+		{name: "d4 does not allow 5 long sides", faces: game.D4, width: 2, height: 5, wantErr: true},
+		{name: "custom faces go up to the highest", faces: game.Faces{1, 1, 2, 3, 4, 6}, width: 5, height: 6, wantErr: false},
+		{name: "zero is never allowed", faces: game.D8, width: 0, height: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.faces.NewPiece("id-player-1", 1, 1, tt.width, tt.height)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewPiece() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && len(p.Coordinates) != int(tt.width)*int(tt.height) {
+				t.Errorf("NewPiece() covers %d cells, want %d", len(p.Coordinates), int(tt.width)*int(tt.height))
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Placement is a spot on the board a rolled piece can be put at.
+type Placement struct {
+	Origin Coordinate
+	Width  uint8
+	Height uint8
+	// Rotated is set if the piece is turned by 90 degrees compared to how it was asked for.
+	Rotated bool
+}
+
+// Piece returns the piece the player would put down with this placement.
+func (pl Placement) Piece(playerID string) (Piece, error) {
+	if pl.Width <= minPieceWidth || pl.Height <= minPieceHeight {
+		return Piece{}, fmt.Errorf("can't create piece with these dimensions: width: %d, height: %d", pl.Width, pl.Height)
+	}
+	return newPiece(playerID, pl.Origin.X, pl.Origin.Y, pl.Width, pl.Height), nil
+}
+
+// LegalPlacements returns every spot where the player can put a piece of the given size, in both orientations. Spots
+// are ordered by orientation first, then row by row from the top left corner. A square piece is only listed once.
+func (b *Board) LegalPlacements(playerID string, width uint8, height uint8) []Placement {
+	if max := b.dice().Max(); width <= minPieceWidth || width > max || height <= minPieceHeight || height > max {
+		return nil
+	}
+
+	g := b.occupancy()
+	f := &fences{b: b, board: &g}
+	opened := b.hasPieces(playerID)
+
+	var legal []Placement
+	legal = b.appendLegalPlacements(legal, g, f, opened, playerID, width, height, false)
+	if width != height {
+		legal = b.appendLegalPlacements(legal, g, f, opened, playerID, height, width, true)
+	}
+
+	return legal
+}
+
+func (b *Board) appendLegalPlacements(legal []Placement, g grid, f *fences, opened bool, playerID string, width uint8, height uint8, rotated bool) []Placement {
+	if width > b.Width || height > b.Height {
+		return legal
+	}
+
+	// Counting in uint8 would wrap around on boards as wide or high as it goes.
+	for y := 1; y <= int(b.Height)-int(height)+1; y++ {
+		for x := 1; x <= int(b.Width)-int(width)+1; x++ {
+			p := Piece{Player: playerID, Origin: Coordinate{X: uint8(x), Y: uint8(y)}, Width: width, Height: height}
+			if b.canPlace(g, f, p, opened) {
+				legal = append(legal, Placement{Origin: p.Origin, Width: width, Height: height, Rotated: rotated})
+			}
+		}
+	}
+
+	return legal
+}
+
+var (
+	// ErrOutOfBounds is returned when a piece doesn't fit on the board or covers a cell blocked by its map.
+	ErrOutOfBounds = errors.New("piece is off the board")
+	// ErrOverlap is returned when a piece covers a cell of another piece.
+	ErrOverlap = errors.New("piece overlaps another piece")
+	// ErrNotAdjacent is returned when a piece doesn't border the pieces it has to: the player's own, or another
+	// player's with ContactRequired. The first piece of a player has to cover their starting corner instead.
+	ErrNotAdjacent = errors.New("piece does not border the pieces it has to")
+	// ErrWrongPlayer is returned when a piece belongs to someone who isn't playing on the board. Games turn down
+	// players placing out of turn with ErrNotYourTurn before it comes to that.
+	ErrWrongPlayer = errors.New("piece belongs to someone who is not playing")
+	// ErrWrongDimensions is returned when a piece doesn't match the roll or is larger than the dice go.
+	ErrWrongDimensions = errors.New("piece does not match the dice")
+	// ErrNotAllowed is returned when the ruleset turns a piece down for any other reason, like touching other players
+	// with ContactForbidden or reaching into their territory with ProtectTerritory.
+	ErrNotAllowed = errors.New("ruleset does not allow the piece there")
+)
+
+// PlacementError describes why a piece can't be placed, so a UI can point at what's wrong.
+type PlacementError struct {
+	Piece Piece
+	// Err is, or wraps, one of ErrOutOfBounds, ErrOverlap, ErrNotAdjacent, ErrWrongPlayer, ErrWrongDimensions or ErrNotAllowed.
+	Err error
+	// At is the first cell at fault, row by row from the top left: the first one off the board, covered by another
+	// piece, next to another player's piece or in their territory. A first piece that misses the starting corner
+	// points at the corner. Anything else is the fault of the piece as a whole, and points at its origin.
+	At Coordinate
+	// Other is the piece in the way at At, if there is one.
+	Other *Piece
+}
+
+func (e *PlacementError) Error() string {
+	msg := fmt.Sprintf("game: can't place %dx%d piece of %q at %s: %v", e.Piece.Width, e.Piece.Height, e.Piece.Player, formatCell(e.Piece.Origin), e.Err)
+	if e.Other != nil {
+		msg += fmt.Sprintf(", %s is covered by %q", formatCell(e.At), e.Other.Player)
+	} else if e.At != e.Piece.Origin {
+		msg += fmt.Sprintf(" at %s", formatCell(e.At))
+	}
+	return msg
+}
+
+func (e *PlacementError) Unwrap() error {
+	return e.Err
+}
+
+// placementError works out why canPlacePiece turned the piece down. It's only called once a piece is refused, so the
+// fast checks don't have to keep track of why.
+func (b *Board) placementError(p Piece) *PlacementError {
+	e := &PlacementError{Piece: p, At: p.Origin}
+	if b.outOfBounds(e) {
+		return e
+	}
+
+	r, standard := b.ruleset().(Rules)
+	anywhere := b.anywhere(&p)
+	if !standard && !anywhere {
+		// There's no telling why a ruleset of someone else's said no.
+		e.Err = ErrNotAllowed
+		return e
+	}
+
+	g := b.occupancyAround(p)
+	opened := b.hasPieces(p.Player)
+	if overlaps(e, g, r, anywhere) || b.intoTerritory(e, g, r, anywhere, opened) || touchesOpponent(e, g, r) {
+		return e
+	}
+
+	e.Err = ErrNotAdjacent
+	if corner, found := b.startingCorner(p.Player); found && !opened {
+		e.At = corner
+	}
+	return e
+}
+
+// outOfBounds finds the first cell of the piece that is off the board or blocked by its map.
+func (b *Board) outOfBounds(e *PlacementError) bool {
+	left, top := int(e.Piece.Origin.X), int(e.Piece.Origin.Y)
+	right, bottom := left+int(e.Piece.Width)-1, top+int(e.Piece.Height)-1
+
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			if x > math.MaxUint8 || y > math.MaxUint8 || !b.IsCoordinateWithin(Coordinate{X: uint8(x), Y: uint8(y)}) {
+				e.Err, e.At = ErrOutOfBounds, Coordinate{X: uint8(minInt(x, math.MaxUint8)), Y: uint8(minInt(y, math.MaxUint8))}
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlaps finds the first cell of the piece that is covered by a piece it can't go on top of.
+func overlaps(e *PlacementError, g grid, r Rules, anywhere bool) bool {
+	left, top := int(e.Piece.Origin.X), int(e.Piece.Origin.Y)
+	right, bottom := left+int(e.Piece.Width)-1, top+int(e.Piece.Height)-1
+
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			if g.isFree(x, y) || (!anywhere && r.OverlapOwn && g.owner(x, y) == e.Piece.Player) {
+				continue
+			}
+			e.Err, e.At, e.Other = ErrOverlap, Coordinate{X: uint8(x), Y: uint8(y)}, g.piece(x, y)
+			return true
+		}
+	}
+	return false
+}
+
+// intoTerritory checks whether reaching into someone's territory is why the piece is turned down, which is the reason
+// whatever else is wrong with it. It finds the first cell of the territory the piece covers.
+func (b *Board) intoTerritory(e *PlacementError, g grid, r Rules, anywhere bool, opened bool) bool {
+	f := &fences{b: b}
+	loose := r
+	loose.ProtectTerritory = false
+	if !anywhere && !loose.allows(b, g, f, &e.Piece, opened) {
+		return false
+	}
+
+	e.Err = ErrNotAllowed
+	if c, ok := f.trespassAt(&e.Piece); ok {
+		e.At = c
+	}
+	return true
+}
+
+// touchesOpponent finds the first cell of an opponent the piece borders, if the rules forbid contact with them.
+func touchesOpponent(e *PlacementError, g grid, r Rules) bool {
+	if r.Opponents != ContactForbidden {
+		return false
+	}
+
+	left, top := int(e.Piece.Origin.X), int(e.Piece.Origin.Y)
+	right, bottom := left+int(e.Piece.Width)-1, top+int(e.Piece.Height)-1
+
+	for y := top - 1; y <= bottom+1; y++ {
+		for x := left - 1; x <= right+1; x++ {
+			corner := (x < left || x > right) && (y < top || y > bottom)
+			if owner := g.owner(x, y); corner || owner == "" || owner == e.Piece.Player {
+				continue
+			}
+			e.Err, e.At, e.Other = ErrNotAllowed, Coordinate{X: uint8(x), Y: uint8(y)}, g.piece(x, y)
+			return true
+		}
+	}
+	return false
+}
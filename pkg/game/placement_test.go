@@ -0,0 +1,282 @@
+package game_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestBoard_LegalPlacements(t *testing.T) {
+	type args struct {
+		playerID string
+		width    uint8
+		height   uint8
+	}
+	tests := []struct {
+		name   string
+		size   game.Coordinate
+		pieces []game.Piece
+		args   args
+		want   []game.Placement
+	}{
+		{
+			name:   "opening square piece has to cover the corner",
+			size:   game.Coordinate{X: 8, Y: 12},
+			pieces: nil,
+			args:   args{playerID: "playerOne", width: 2, height: 2},
+			want: []game.Placement{
+				{Origin: game.Coordinate{X: 1, Y: 1}, Width: 2, Height: 2},
+			},
+		},
+		{
+			name:   "opening piece is listed in both orientations",
+			size:   game.Coordinate{X: 8, Y: 12},
+			pieces: nil,
+			args:   args{playerID: "playerTwo", width: 1, height: 2},
+			want: []game.Placement{
+				{Origin: game.Coordinate{X: 8, Y: 11}, Width: 1, Height: 2},
+				{Origin: game.Coordinate{X: 7, Y: 12}, Width: 2, Height: 1, Rotated: true},
+			},
+		},
+		{
+			name: "pieces grow from own territory without overlapping",
+			size: game.Coordinate{X: 8, Y: 12},
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 1, 1),
+				mustNewPiece(t, "playerTwo", 2, 1, 1, 1),
+			},
+			args: args{playerID: "playerOne", width: 1, height: 1},
+			want: []game.Placement{
+				{Origin: game.Coordinate{X: 1, Y: 2}, Width: 1, Height: 1},
+			},
+		},
+		{
+			name:   "pieces larger than the board have nowhere to go",
+			size:   game.Coordinate{X: 5, Y: 5},
+			pieces: nil,
+			args:   args{playerID: "playerOne", width: 6, height: 6},
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := game.Board{
+				Width:  tt.size.X,
+				Height: tt.size.Y,
+				Players: []game.Player{
+					{ID: "playerOne", Name: "playerOne"},
+					{ID: "playerTwo", Name: "playerTwo"},
+				},
+				Corners: []game.Coordinate{
+					{X: 1, Y: 1},
+					{X: tt.size.X, Y: tt.size.Y},
+				},
+				Pieces: tt.pieces,
+			}
+
+			got := b.LegalPlacements(tt.args.playerID, tt.args.width, tt.args.height)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LegalPlacements() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoard_LegalPlacements_AllPlaceable(t *testing.T) {
+	b, err := game.NewBoard(12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for _, p := range []game.Piece{
+		mustNewPiece(t, "playerOne", 1, 1, 3, 4),
+		mustNewPiece(t, "playerTwo", 10, 13, 3, 4),
+		mustNewPiece(t, "playerOne", 4, 2, 5, 2),
+	} {
+		if _, err := b.PlacePiece(p); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+
+	placements := b.LegalPlacements("playerOne", 2, 3)
+	if len(placements) == 0 {
+		t.Fatalf("LegalPlacements() found nothing")
+	}
+
+	for _, pl := range placements {
+		local := b
+		local.Pieces = append([]game.Piece(nil), b.Pieces...)
+
+		p, err := pl.Piece("playerOne")
+		if err != nil {
+			t.Fatalf("Piece() error = %v", err)
+		}
+		if _, err := local.PlacePiece(p); err != nil {
+			t.Errorf("PlacePiece() of legal placement %v error = %v", pl, err)
+		}
+	}
+}
+
+func TestBoard_LegalPlacements_LargestBoards(t *testing.T) {
+	bounds := game.Bounds{MinWidth: 2, MaxWidth: math.MaxUint8, MinHeight: 2, MaxHeight: math.MaxUint8}
+	tests := []struct {
+		name   string
+		width  uint8
+		height uint8
+	}{
+		{name: "widest", width: math.MaxUint8, height: 12},
+		{name: "highest", width: 12, height: math.MaxUint8},
+		{name: "largest", width: math.MaxUint8, height: math.MaxUint8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := bounds.NewBoard(tt.width, tt.height, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+
+			want := []game.Placement{{Origin: game.Coordinate{X: tt.width, Y: tt.height}, Width: 1, Height: 1}}
+			if got := b.LegalPlacements("playerTwo", 1, 1); !reflect.DeepEqual(got, want) {
+				t.Errorf("LegalPlacements() got = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func mustNewPiece(t *testing.T, player string, x uint8, y uint8, width uint8, height uint8) game.Piece {
+	t.Helper()
+
+	p, err := game.NewPiece(player, x, y, width, height)
+	if err != nil {
+		t.Fatalf("NewPiece() error = %v", err)
+	}
+	return p
+}
+
+func TestBoard_PlacePiece_PlacementError(t *testing.T) {
+	playerTwo := mustNewPiece(t, "playerTwo", 3, 1, 1, 1)
+	tests := []struct {
+		name      string
+		ruleset   game.Ruleset
+		pieces    []game.Piece
+		roll      *game.Roll
+		piece     game.Piece
+		wantErr   error
+		wantAt    game.Coordinate
+		wantOther *game.Piece
+	}{
+		{
+			name:    "off the board",
+			pieces:  []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 1, 1)},
+			piece:   mustNewPiece(t, "playerOne", 7, 2, 3, 2),
+			wantErr: game.ErrOutOfBounds,
+			wantAt:  game.Coordinate{X: 9, Y: 2},
+		},
+		{
+			name:      "overlaps another player",
+			pieces:    []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 1, 1), playerTwo},
+			piece:     mustNewPiece(t, "playerOne", 2, 1, 2, 2),
+			wantErr:   game.ErrOverlap,
+			wantAt:    game.Coordinate{X: 3, Y: 1},
+			wantOther: &playerTwo,
+		},
+		{
+			name:    "not bordering own pieces",
+			pieces:  []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 1, 1)},
+			piece:   mustNewPiece(t, "playerOne", 5, 5, 1, 1),
+			wantErr: game.ErrNotAdjacent,
+			wantAt:  game.Coordinate{X: 5, Y: 5},
+		},
+		{
+			name:    "first piece missing the starting corner",
+			piece:   mustNewPiece(t, "playerOne", 3, 3, 2, 2),
+			wantErr: game.ErrNotAdjacent,
+			wantAt:  game.Coordinate{X: 1, Y: 1},
+		},
+		{
+			name:    "player not playing",
+			piece:   mustNewPiece(t, "playerThree", 1, 1, 1, 1),
+			wantErr: game.ErrWrongPlayer,
+			wantAt:  game.Coordinate{X: 1, Y: 1},
+		},
+		{
+			name:    "piece not matching the roll",
+			roll:    &game.Roll{Width: 2, Height: 3},
+			piece:   mustNewPiece(t, "playerOne", 1, 1, 2, 2),
+			wantErr: game.ErrWrongDimensions,
+			wantAt:  game.Coordinate{X: 1, Y: 1},
+		},
+		{
+			name:      "touching another player when contact is forbidden",
+			ruleset:   game.Rules{Opponents: game.ContactForbidden},
+			pieces:    []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 1, 1), playerTwo},
+			piece:     mustNewPiece(t, "playerOne", 2, 1, 1, 2),
+			wantErr:   game.ErrNotAllowed,
+			wantAt:    game.Coordinate{X: 3, Y: 1},
+			wantOther: &playerTwo,
+		},
+		{
+			name:    "reaching into protected territory",
+			ruleset: game.Rules{Opponents: game.ContactRequired, ProtectTerritory: true},
+			pieces:  append(enclosingPieces(t), mustNewPiece(t, "playerTwo", 8, 12, 1, 1)),
+			piece:   mustNewPiece(t, "playerTwo", 1, 2, 1, 1),
+			wantErr: game.ErrNotAllowed,
+			wantAt:  game.Coordinate{X: 1, Y: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Ruleset = tt.ruleset
+			b.Pieces = append(b.Pieces, tt.pieces...)
+			b.Roll = tt.roll
+
+			_, err = b.PlacePiece(tt.piece)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PlacePiece() error = %v, want %v", err, tt.wantErr)
+			}
+			var pe *game.PlacementError
+			if !errors.As(err, &pe) {
+				t.Fatalf("PlacePiece() error = %v, want a *PlacementError", err)
+			}
+			if pe.At != tt.wantAt {
+				t.Errorf("PlacePiece() error at %v, want %v", pe.At, tt.wantAt)
+			}
+			if !reflect.DeepEqual(pe.Other, tt.wantOther) {
+				t.Errorf("PlacePiece() error other piece = %v, want %v", pe.Other, tt.wantOther)
+			}
+			if len(b.Pieces) != len(tt.pieces) {
+				t.Errorf("PlacePiece() placed the piece anyway")
+			}
+		})
+	}
+}
+
+func TestGame_Place_PlacementError(t *testing.T) {
+	g, err := game.New("1", 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	d, err := game.NewDice(&fixedSource{values: []int{1, 2}})
+	if err != nil {
+		t.Fatalf("NewDice() error = %v", err)
+	}
+	g.Dice = d
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+
+	err = g.Place("playerOne", game.Coordinate{X: 4, Y: 4}, false)
+	var pe *game.PlacementError
+	if !errors.As(err, &pe) || !errors.Is(err, game.ErrNotAdjacent) {
+		t.Fatalf("Place() error = %v, want %v", err, game.ErrNotAdjacent)
+	}
+	if want := (game.Coordinate{X: 1, Y: 1}); pe.At != want {
+		t.Errorf("Place() error at %v, want %v", pe.At, want)
+	}
+}
@@ -0,0 +1,217 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	glyphFree      = '.'
+	glyphBlocked   = '#'
+	glyphHighlight = '*'
+	playerGlyphs   = "abcdefghijklmnopqrstuvwxyz"
+)
+
+// ansiColors are the colours of the players' pieces when rendering with colour, in the order the players joined.
+var ansiColors = []string{"\x1b[31m", "\x1b[34m", "\x1b[32m", "\x1b[33m"}
+
+const (
+	ansiReverse = "\x1b[7m"
+	ansiReset   = "\x1b[0m"
+)
+
+// RenderOptions controls what Render draws on top of the pieces.
+type RenderOptions struct {
+	// Color draws the pieces of each player in their own ANSI colour.
+	Color bool
+	// Corners marks the starting corners of players that haven't opened yet with their number.
+	Corners bool
+	// Highlight marks the free cells covered by these placements, eg the legal placements of a roll.
+	Highlight []Placement
+}
+
+// String draws the board without any extras, see Render.
+func (b Board) String() string {
+	return b.Render(RenderOptions{})
+}
+
+// Render draws the board as text with columns labelled by letters and rows by numbers. Every cell shows the letter of
+// the player covering it, a for the first player, b for the second, and so on, a dot if it's free, or a # if the map
+// blocks it. Pieces are outlined with lines along their edges.
+func (b Board) Render(opts RenderOptions) string {
+	r := textRenderer{
+		b:           &b,
+		g:           b.occupancy(),
+		opts:        opts,
+		glyphs:      b.playerGlyphs(),
+		highlighted: b.highlighted(opts.Highlight),
+		corners:     make(map[Coordinate]int),
+		labelWidth:  len(fmt.Sprint(b.Height)),
+	}
+	if opts.Corners {
+		for i, p := range b.Players {
+			if i < len(b.Corners) && !b.hasPieces(p.ID) {
+				r.corners[b.Corners[i]] = i + 1
+			}
+		}
+	}
+
+	r.columnLabels()
+	for y := 0; y <= r.g.height; y++ {
+		r.borderLine(y)
+		if y < r.g.height {
+			r.cellRow(y + 1)
+		}
+	}
+	return r.sb.String()
+}
+
+// textRenderer draws a board as text, line by line.
+type textRenderer struct {
+	b           *Board
+	g           grid
+	opts        RenderOptions
+	glyphs      map[string]byte
+	highlighted map[Coordinate]bool
+	// corners are the numbers of the players whose starting corners are marked, by corner.
+	corners    map[Coordinate]int
+	labelWidth int
+	sb         strings.Builder
+}
+
+// piece returns the index of the piece covering a cell, or 0 for free cells and cells off the board.
+func (r *textRenderer) piece(x int, y int) int32 {
+	if !r.g.contains(x, y) {
+		return 0
+	}
+	return r.g.cells[r.g.index(x, y)]
+}
+
+// edge checks whether there is a border between two neighbouring cells.
+func (r *textRenderer) edge(x1 int, y1 int, x2 int, y2 int) bool {
+	return r.piece(x1, y1) != r.piece(x2, y2) || r.g.contains(x1, y1) != r.g.contains(x2, y2)
+}
+
+// columnLabels draws the column labels, each cell is two characters wide. Only the last letter of long names fits.
+func (r *textRenderer) columnLabels() {
+	r.sb.WriteString(strings.Repeat(" ", r.labelWidth+2))
+	for x := 1; x <= r.g.width; x++ {
+		name := ColumnName(x)
+		r.sb.WriteString(name[len(name)-1:])
+		r.sb.WriteByte(' ')
+	}
+	r.sb.WriteString("\n")
+}
+
+// borderLine draws the line of borders above row y+1, between row y and row y+1.
+func (r *textRenderer) borderLine(y int) {
+	r.sb.WriteString(strings.Repeat(" ", r.labelWidth+1))
+	for x := 0; x <= r.g.width; x++ {
+		up := r.edge(x, y, x+1, y)
+		down := r.edge(x, y+1, x+1, y+1)
+		left := r.edge(x, y, x, y+1)
+		right := r.edge(x+1, y, x+1, y+1)
+		switch {
+		case (up || down) && (left || right):
+			r.sb.WriteByte('+')
+		case up || down:
+			r.sb.WriteByte('|')
+		case left || right:
+			r.sb.WriteByte('-')
+		default:
+			r.sb.WriteByte(' ')
+		}
+		if x < r.g.width {
+			if right {
+				r.sb.WriteByte('-')
+			} else {
+				r.sb.WriteByte(' ')
+			}
+		}
+	}
+	r.sb.WriteString("\n")
+}
+
+// cellRow draws the row itself, cells with borders between them.
+func (r *textRenderer) cellRow(row int) {
+	fmt.Fprintf(&r.sb, "%*d ", r.labelWidth, row)
+	for x := 0; x <= r.g.width; x++ {
+		if r.edge(x, row, x+1, row) {
+			r.sb.WriteByte('|')
+		} else {
+			r.sb.WriteByte(' ')
+		}
+		if x < r.g.width {
+			r.cell(x+1, row)
+		}
+	}
+	r.sb.WriteString("\n")
+}
+
+// cell draws what is on a cell.
+func (r *textRenderer) cell(x int, y int) {
+	c := Coordinate{X: uint8(x), Y: uint8(y)}
+	i := r.piece(x, y)
+	switch {
+	case i != 0:
+		player := r.g.pieces[i-1].Player
+		glyph := string(r.glyphs[player])
+		if r.opts.Color {
+			glyph = r.b.playerColor(player) + glyph + ansiReset
+		}
+		r.sb.WriteString(glyph)
+	case r.corners[c] != 0:
+		r.sb.WriteString(fmt.Sprint(r.corners[c]))
+	case !r.b.IsCoordinateWithin(c):
+		r.sb.WriteByte(glyphBlocked)
+	case r.highlighted[c]:
+		if r.opts.Color {
+			r.sb.WriteString(ansiReverse + string(glyphHighlight) + ansiReset)
+		} else {
+			r.sb.WriteByte(glyphHighlight)
+		}
+	default:
+		r.sb.WriteByte(glyphFree)
+	}
+}
+
+// playerGlyphs gives every player a letter: the players of the board first, in the order they joined, then anyone
+// else who has pieces on the board in the order of their first piece.
+func (b Board) playerGlyphs() map[string]byte {
+	glyphs := make(map[string]byte)
+	add := func(player string) {
+		if _, ok := glyphs[player]; !ok {
+			glyphs[player] = playerGlyphs[len(glyphs)%len(playerGlyphs)]
+		}
+	}
+
+	for _, p := range b.Players {
+		add(p.ID)
+	}
+	for _, p := range b.Pieces {
+		add(p.Player)
+	}
+
+	return glyphs
+}
+
+func (b Board) playerColor(player string) string {
+	for i, p := range b.Players {
+		if p.ID == player {
+			return ansiColors[i%len(ansiColors)]
+		}
+	}
+	return ""
+}
+
+func (b Board) highlighted(placements []Placement) map[Coordinate]bool {
+	cells := make(map[Coordinate]bool)
+	for _, pl := range placements {
+		for y := 0; y < int(pl.Height); y++ {
+			for x := 0; x < int(pl.Width); x++ {
+				cells[Coordinate{X: pl.Origin.X + uint8(x), Y: pl.Origin.Y + uint8(y)}] = true
+			}
+		}
+	}
+	return cells
+}
@@ -0,0 +1,129 @@
+package game_test
+
+import (
+	"strings"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestBoard_Render(t *testing.T) {
+	board := func() game.Board {
+		return game.Board{
+			Width:  5,
+			Height: 3,
+			Players: []game.Player{
+				{ID: "playerOne", Name: "playerOne"},
+				{ID: "playerTwo", Name: "playerTwo"},
+			},
+			Corners: []game.Coordinate{
+				{X: 1, Y: 1},
+				{X: 5, Y: 3},
+			},
+			Pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 2, 2),
+				mustNewPiece(t, "playerOne", 3, 1, 1, 1),
+			},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		board game.Board
+		opts  game.RenderOptions
+		want  []string
+	}{
+		{
+			name:  "pieces are outlined",
+			board: board(),
+			opts:  game.RenderOptions{},
+			want: []string{
+				"   A B C D E ",
+				"  +---+-+---+",
+				"1 |a a|a|. .|",
+				"  |   +-+   |",
+				"2 |a a|. . .|",
+				"  +---+     |",
+				"3 |. . . . .|",
+				"  +---------+",
+			},
+		},
+		{
+			name:  "free corners and highlighted placements",
+			board: board(),
+			opts: game.RenderOptions{
+				Corners: true,
+				Highlight: []game.Placement{
+					{Origin: game.Coordinate{X: 4, Y: 1}, Width: 1, Height: 2},
+				},
+			},
+			want: []string{
+				"   A B C D E ",
+				"  +---+-+---+",
+				"1 |a a|a|* .|",
+				"  |   +-+   |",
+				"2 |a a|. * .|",
+				"  +---+     |",
+				"3 |. . . . 2|",
+				"  +---------+",
+			},
+		},
+		{
+			name:  "colour per player",
+			board: board(),
+			opts:  game.RenderOptions{Color: true},
+			want: []string{
+				"   A B C D E ",
+				"  +---+-+---+",
+				"1 |\x1b[31ma\x1b[0m \x1b[31ma\x1b[0m|\x1b[31ma\x1b[0m|. .|",
+				"  |   +-+   |",
+				"2 |\x1b[31ma\x1b[0m \x1b[31ma\x1b[0m|. . .|",
+				"  +---+     |",
+				"3 |. . . . .|",
+				"  +---------+",
+			},
+		},
+		{
+			name: "cells blocked by the map",
+			board: func() game.Board {
+				b := board()
+				m, err := game.ParseMap("test", "1....\n..##.\n....2\n")
+				if err != nil {
+					t.Fatalf("ParseMap() error = %v", err)
+				}
+				b.Map = m
+				return b
+			}(),
+			opts: game.RenderOptions{},
+			want: []string{
+				"   A B C D E ",
+				"  +---+-+---+",
+				"1 |a a|a|. .|",
+				"  |   +-+   |",
+				"2 |a a|# # .|",
+				"  +---+     |",
+				"3 |. . . . .|",
+				"  +---------+",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := strings.Join(tt.want, "\n") + "\n"
+			if got := tt.board.Render(tt.opts); got != want {
+				t.Errorf("Render() got = \n%s, want \n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestBoard_String(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+
+	if got, want := b.String(), b.Render(game.RenderOptions{}); got != want {
+		t.Errorf("String() got = \n%s, want \n%s", got, want)
+	}
+}
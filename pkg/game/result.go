@@ -0,0 +1,115 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EndReason is why a game ended.
+type EndReason uint8
+
+const (
+	// NotEnded is the reason of a game that is still going.
+	NotEnded EndReason = iota
+	// EndPassLimit means the players passed as many times in a row as the pass limit allows.
+	EndPassLimit
+	// EndBoardFull means there is no free cell left on the board.
+	EndBoardFull
+	// EndForfeit means all players but one ran out of time and forfeited the game.
+	EndForfeit
+)
+
+func (r EndReason) String() string {
+	switch r {
+	case NotEnded:
+		return "not ended"
+	case EndPassLimit:
+		return "pass limit reached"
+	case EndBoardFull:
+		return "board full"
+	case EndForfeit:
+		return "forfeit"
+	default:
+		return fmt.Sprintf("unknown reason %d", uint8(r))
+	}
+}
+
+// ErrNotOver is returned when asking for the result of a game that is still going.
+var ErrNotOver = errors.New("game is not over yet")
+
+// Result is the outcome of a finished game.
+type Result struct {
+	// Winner is the ID of the winning player. Empty if the game is a draw.
+	Winner string
+	Draw   bool
+	// Area is the number of cells each player covers, keyed by player ID.
+	Area map[string]uint
+	// Score is the final score of each player, keyed by player ID.
+	Score  map[string]uint
+	Reason EndReason
+}
+
+// Result returns the outcome of the game once it's over. The player with the highest score wins, if more players share
+// the highest score, it's a draw. A player who forfeited can't win.
+func (g *Game) Result() (Result, error) {
+	if g.Phase != GameOver {
+		return Result{}, ErrNotOver
+	}
+
+	r := Result{
+		Area:   make(map[string]uint, len(g.Board.Players)),
+		Score:  make(map[string]uint, len(g.Board.Players)),
+		Reason: g.Ended,
+	}
+
+	s := g.Board.scorer()
+	var best uint
+	for i, p := range g.Board.Players {
+		r.Area[p.ID] = g.Board.CoveredArea(p.ID)
+		r.Score[p.ID] = s.Score(&g.Board, p.ID)
+		if r.Score[p.ID] > best && !g.forfeited(i) {
+			best = r.Score[p.ID]
+		}
+	}
+
+	var leaders []string
+	for i, p := range g.Board.Players {
+		if r.Score[p.ID] == best && !g.forfeited(i) {
+			leaders = append(leaders, p.ID)
+		}
+	}
+
+	if len(leaders) == 1 {
+		r.Winner = leaders[0]
+	} else {
+		r.Draw = true
+	}
+
+	return r, nil
+}
+
+// forfeited checks whether the player at the index ran out of time and forfeited. They are out of the game: their turns
+// are skipped, and they can't win.
+func (g *Game) forfeited(player int) bool {
+	if g.Config.Time.OnTimeout != TimeoutForfeit {
+		return false
+	}
+	id := g.Board.Players[player].ID
+	for _, m := range g.History {
+		if m.Kind == MoveTimeout && m.Player == id {
+			return true
+		}
+	}
+	return false
+}
+
+// playing returns the number of players still in the game.
+func (g *Game) playing() int {
+	n := 0
+	for i := range g.Board.Players {
+		if !g.forfeited(i) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,168 @@
+package game_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestGame_Result(t *testing.T) {
+	tests := []struct {
+		name    string
+		pieces  []game.Piece
+		phase   game.Phase
+		ended   game.EndReason
+		want    game.Result
+		wantErr error
+	}{
+		{
+			name: "player with the most area wins",
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 3, 2),
+				mustNewPiece(t, "playerTwo", 12, 16, 1, 1),
+			},
+			phase: game.GameOver,
+			ended: game.EndPassLimit,
+			want: game.Result{
+				Winner: "playerOne",
+				Area:   map[string]uint{"playerOne": 6, "playerTwo": 1},
+				Score:  map[string]uint{"playerOne": 6, "playerTwo": 1},
+				Reason: game.EndPassLimit,
+			},
+		},
+		{
+			name: "same area is a draw",
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 3, 2),
+				mustNewPiece(t, "playerTwo", 11, 14, 2, 3),
+			},
+			phase: game.GameOver,
+			ended: game.EndBoardFull,
+			want: game.Result{
+				Draw:   true,
+				Area:   map[string]uint{"playerOne": 6, "playerTwo": 6},
+				Score:  map[string]uint{"playerOne": 6, "playerTwo": 6},
+				Reason: game.EndBoardFull,
+			},
+		},
+		{
+			name:    "no result while the game is going",
+			phase:   game.AwaitingRoll,
+			want:    game.Result{},
+			wantErr: game.ErrNotOver,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.New("1", 12, 16, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			g.Board.Pieces = tt.pieces
+			g.Phase = tt.phase
+			g.Ended = tt.ended
+
+			got, err := g.Result()
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Result() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Result() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGame_EndsAfterPassLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    game.Config
+		wantPhase []game.Phase
+	}{
+		{
+			name:      "default limit is a pass for every player",
+			config:    game.Config{},
+			wantPhase: []game.Phase{game.AwaitingRoll, game.GameOver},
+		},
+		{
+			name:      "custom limit",
+			config:    game.Config{PassLimit: 3},
+			wantPhase: []game.Phase{game.AwaitingRoll, game.AwaitingRoll, game.GameOver},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(t, 1)
+			g.Config = tt.config
+
+			for i, want := range tt.wantPhase {
+				player := g.CurrentPlayer().ID
+				if _, err := g.Roll(player); err != nil {
+					t.Fatalf("Roll() error = %v", err)
+				}
+				if err := g.Pass(player); err != nil {
+					t.Fatalf("Pass() error = %v", err)
+				}
+				if g.Phase != want {
+					t.Fatalf("Pass() #%d phase = %v, want %v", i, g.Phase, want)
+				}
+			}
+
+			if g.Ended != game.EndPassLimit {
+				t.Errorf("Pass() ended = %v, want %v", g.Ended, game.EndPassLimit)
+			}
+		})
+	}
+}
+
+func TestGame_AutoPassWhenStuck(t *testing.T) {
+	g := newTestGame(t, 6)
+	// Wall player one in with player two's pieces.
+	g.Board.Pieces = append(g.Board.Pieces,
+		mustNewPiece(t, "playerTwo", 2, 1, 1, 2),
+		mustNewPiece(t, "playerTwo", 1, 2, 1, 1),
+	)
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if g.Phase != game.AwaitingRoll || g.CurrentPlayer().ID != "playerTwo" || g.Passes != 1 {
+		t.Fatalf("Roll() did not pass, phase = %v, current = %s, passes = %d", g.Phase, g.CurrentPlayer().ID, g.Passes)
+	}
+
+	if _, err := g.Roll("playerTwo"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerTwo", game.Coordinate{X: 7, Y: 10}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+	if g.Passes != 0 {
+		t.Errorf("Place() did not reset passes, got %d", g.Passes)
+	}
+}
+
+func TestGame_EndsWhenBoardFull(t *testing.T) {
+	g := newTestGame(t, 1)
+	g.Board.Pieces = nil
+	for y := uint8(1); y <= g.Board.Height; y++ {
+		for x := uint8(1); x <= g.Board.Width; x++ {
+			if x == 2 && y == 1 {
+				continue
+			}
+			g.Board.Pieces = append(g.Board.Pieces, mustNewPiece(t, "playerOne", x, y, 1, 1))
+		}
+	}
+
+	if _, err := g.Roll("playerOne"); err != nil {
+		t.Fatalf("Roll() error = %v", err)
+	}
+	if err := g.Place("playerOne", game.Coordinate{X: 2, Y: 1}, false); err != nil {
+		t.Fatalf("Place() error = %v", err)
+	}
+
+	if g.Phase != game.GameOver || g.Ended != game.EndBoardFull {
+		t.Errorf("Place() phase = %v, ended = %v, want %v, %v", g.Phase, g.Ended, game.GameOver, game.EndBoardFull)
+	}
+}
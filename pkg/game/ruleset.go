@@ -0,0 +1,152 @@
+package game
+
+import "fmt"
+
+// Ruleset decides where pieces can be placed.
+type Ruleset interface {
+	// CanPlace checks whether the piece can be placed on the board. All of its cells are on the board. Opened is whether
+	// the player has pieces on the board already.
+	CanPlace(b *Board, p Piece, opened bool) bool
+}
+
+// Contact is what a ruleset says about pieces touching the pieces of other players.
+type Contact uint8
+
+const (
+	// ContactAllowed lets pieces touch the pieces of other players, but doesn't require it.
+	ContactAllowed Contact = iota
+	// ContactRequired means pieces have to border a piece of another player instead of one of the player's own.
+	ContactRequired
+	// ContactForbidden means pieces can't border the pieces of other players.
+	ContactForbidden
+)
+
+func (c Contact) String() string {
+	switch c {
+	case ContactAllowed:
+		return "allowed"
+	case ContactRequired:
+		return "required"
+	case ContactForbidden:
+		return "forbidden"
+	default:
+		return fmt.Sprintf("unknown contact %d", uint8(c))
+	}
+}
+
+// Rules are the standard placement rules with switches for the variants. The zero value is the standard game: pieces
+// can't overlap, the first piece of a player covers their starting corner, and every other piece borders one of the
+// player's own along an edge. Touching at a corner doesn't count.
+type Rules struct {
+	// CornerTouch lets pieces that only touch the player's own at a corner count as bordering them.
+	CornerTouch bool
+	// Opponents says whether pieces after the first have to, may or must not border the pieces of other players.
+	// Corners don't count.
+	Opponents Contact
+	// OverlapOwn lets pieces cover cells of the player's own pieces. Overlapping counts as bordering them.
+	OverlapOwn bool
+	// ProtectTerritory keeps pieces out of the territory other players have enclosed, see Board.Territories. It only
+	// makes a difference with variants that let pieces reach into it, like ContactRequired or CornerTouch.
+	ProtectTerritory bool
+}
+
+// CanPlace checks the piece against the rules.
+func (r Rules) CanPlace(b *Board, p Piece, opened bool) bool {
+	return r.allows(b, b.occupancyAround(p), &fences{b: b}, &p, opened)
+}
+
+// allows checks the piece against the rules, one rule after the other, with a grid that covers the piece and its
+// borders. The fences are shared by the checks on the same board.
+func (r Rules) allows(b *Board, g grid, f *fences, p *Piece, opened bool) bool {
+	own, ok := r.overlaps(g, p)
+	if !ok {
+		return false
+	}
+
+	own, other := r.contacts(g, p, opened, own)
+	if r.Opponents == ContactForbidden && other {
+		return false
+	}
+	if !r.connects(b, p, opened, own, other) {
+		return false
+	}
+
+	// Finding the territories means flood filling the whole board, so it's only done for otherwise legal pieces.
+	return !(r.ProtectTerritory && trespasses(f, p))
+}
+
+// overlaps checks that the piece only covers free cells, or cells of the player's own if the variant lets it, and
+// reports whether it covers any of their own.
+func (r Rules) overlaps(g grid, p *Piece) (own bool, ok bool) {
+	left, top := int(p.Origin.X), int(p.Origin.Y)
+	right, bottom := left+int(p.Width)-1, top+int(p.Height)-1
+
+	for y := top; y <= bottom; y++ {
+		for x := left; x <= right; x++ {
+			owner := g.owner(x, y)
+			if owner == "" {
+				continue
+			}
+			if !r.OverlapOwn || owner != p.Player {
+				return false, false
+			}
+			own = true
+		}
+	}
+	return own, true
+}
+
+// contacts reports whether the piece borders pieces of the player's own and of other players. Corners don't count,
+// unless the variant says so for the player's own. In the standard game the first piece of their own settles it.
+func (r Rules) contacts(g grid, p *Piece, opened bool, own bool) (bool, bool) {
+	left, top := int(p.Origin.X), int(p.Origin.Y)
+	right, bottom := left+int(p.Width)-1, top+int(p.Height)-1
+
+	other := false
+	settled := opened && r.Opponents == ContactAllowed
+	for x := left; x <= right && !(settled && own); x++ {
+		own, other = touch(g.owner(x, top-1), p.Player, own, other)
+		own, other = touch(g.owner(x, bottom+1), p.Player, own, other)
+	}
+	for y := top; y <= bottom && !(settled && own); y++ {
+		own, other = touch(g.owner(left-1, y), p.Player, own, other)
+		own, other = touch(g.owner(right+1, y), p.Player, own, other)
+	}
+	if r.CornerTouch && !own {
+		own = g.owner(left-1, top-1) == p.Player || g.owner(right+1, top-1) == p.Player ||
+			g.owner(left-1, bottom+1) == p.Player || g.owner(right+1, bottom+1) == p.Player
+	}
+	return own, other
+}
+
+// connects checks that the piece is where the rules want it: the first piece of a player covers their starting
+// corner, every other one borders a piece of their own, or of another player if the variant requires contact.
+func (r Rules) connects(b *Board, p *Piece, opened bool, own bool, other bool) bool {
+	switch {
+	case !opened:
+		corner, found := b.startingCorner(p.Player)
+		return found && p.IsCoordinateWithin(corner)
+	case r.Opponents == ContactRequired:
+		return other
+	default:
+		return own
+	}
+}
+
+// trespasses checks whether the piece would cover cells enclosed by another player.
+func trespasses(f *fences, p *Piece) bool {
+	_, ok := f.trespassAt(p)
+	return ok
+}
+
+// touch records whether a cell next to a piece of the player is covered by them or by someone else.
+func touch(owner string, player string, own bool, other bool) (bool, bool) {
+	return own || owner == player, other || (owner != "" && owner != player)
+}
+
+func (b *Board) ruleset() Ruleset {
+	if b.Ruleset == nil {
+		return Rules{}
+	}
+	return b.Ruleset
+}
@@ -0,0 +1,206 @@
+package game_test
+
+import (
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestRules_CanPlace(t *testing.T) {
+	// Player one in the top left corner, player two right next to them.
+	pieces := func() []game.Piece {
+		return []game.Piece{
+			mustNewPiece(t, "id-player-1", 1, 1, 2, 2),
+			mustNewPiece(t, "id-player-2", 4, 1, 2, 2),
+		}
+	}
+
+	// Player one walls off A2:B2 in the top left corner, player two is in the bottom right one.
+	enclosed := func() []game.Piece {
+		return []game.Piece{
+			mustNewPiece(t, "id-player-1", 1, 1, 3, 1),
+			mustNewPiece(t, "id-player-1", 3, 2, 1, 2),
+			mustNewPiece(t, "id-player-1", 1, 3, 2, 1),
+			mustNewPiece(t, "id-player-2", 8, 12, 1, 1),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		ruleset  game.Ruleset
+		pieces   []game.Piece
+		piece    game.Piece
+		want     bool
+		wantArea uint
+	}{
+		{
+			name:     "standard: next to own and opponent",
+			ruleset:  nil,
+			piece:    mustNewPiece(t, "id-player-1", 3, 1, 1, 1),
+			want:     true,
+			wantArea: 5,
+		},
+		{
+			name:    "standard: only touching own at a corner",
+			ruleset: game.Rules{},
+			piece:   mustNewPiece(t, "id-player-1", 3, 3, 1, 1),
+			want:    false,
+		},
+		{
+			name:     "corner touch: only touching own at a corner",
+			ruleset:  game.Rules{CornerTouch: true},
+			piece:    mustNewPiece(t, "id-player-1", 3, 3, 1, 1),
+			want:     true,
+			wantArea: 5,
+		},
+		{
+			name:    "corner touch: only touching opponent at a corner",
+			ruleset: game.Rules{CornerTouch: true},
+			piece:   mustNewPiece(t, "id-player-1", 6, 3, 1, 1),
+			want:    false,
+		},
+		{
+			name:    "standard: overlapping own",
+			ruleset: nil,
+			piece:   mustNewPiece(t, "id-player-1", 2, 2, 2, 2),
+			want:    false,
+		},
+		{
+			name:     "overlap own: overlapping own counts cells once",
+			ruleset:  game.Rules{OverlapOwn: true},
+			piece:    mustNewPiece(t, "id-player-1", 2, 2, 2, 2),
+			want:     true,
+			wantArea: 7,
+		},
+		{
+			name:    "overlap own: overlapping opponent",
+			ruleset: game.Rules{OverlapOwn: true},
+			piece:   mustNewPiece(t, "id-player-1", 3, 2, 2, 1),
+			want:    false,
+		},
+		{
+			name:    "opponents forbidden: next to own and opponent",
+			ruleset: game.Rules{Opponents: game.ContactForbidden},
+			piece:   mustNewPiece(t, "id-player-1", 3, 1, 1, 1),
+			want:    false,
+		},
+		{
+			name:     "opponents forbidden: next to own only",
+			ruleset:  game.Rules{Opponents: game.ContactForbidden},
+			piece:    mustNewPiece(t, "id-player-1", 1, 3, 1, 1),
+			want:     true,
+			wantArea: 5,
+		},
+		{
+			name:    "opponents forbidden: opening next to opponent",
+			ruleset: game.Rules{Opponents: game.ContactForbidden},
+			pieces:  []game.Piece{mustNewPiece(t, "id-player-2", 2, 1, 1, 1)},
+			piece:   mustNewPiece(t, "id-player-1", 1, 1, 1, 1),
+			want:    false,
+		},
+		{
+			name:    "opponents required: next to own only",
+			ruleset: game.Rules{Opponents: game.ContactRequired},
+			piece:   mustNewPiece(t, "id-player-1", 1, 3, 1, 1),
+			want:    false,
+		},
+		{
+			name:     "opponents required: next to opponent only",
+			ruleset:  game.Rules{Opponents: game.ContactRequired},
+			piece:    mustNewPiece(t, "id-player-1", 6, 1, 1, 1),
+			want:     true,
+			wantArea: 5,
+		},
+		{
+			name:     "opponents required: reaching into territory",
+			ruleset:  game.Rules{Opponents: game.ContactRequired},
+			pieces:   enclosed(),
+			piece:    mustNewPiece(t, "id-player-2", 1, 2, 1, 1),
+			want:     true,
+			wantArea: 2,
+		},
+		{
+			name:    "protect territory: reaching into territory",
+			ruleset: game.Rules{Opponents: game.ContactRequired, ProtectTerritory: true},
+			pieces:  enclosed(),
+			piece:   mustNewPiece(t, "id-player-2", 1, 2, 1, 1),
+			want:    false,
+		},
+		{
+			name:     "protect territory: next to territory",
+			ruleset:  game.Rules{Opponents: game.ContactRequired, ProtectTerritory: true},
+			pieces:   enclosed(),
+			piece:    mustNewPiece(t, "id-player-2", 4, 1, 1, 1),
+			want:     true,
+			wantArea: 2,
+		},
+		{
+			name:     "protect territory: own territory",
+			ruleset:  game.Rules{ProtectTerritory: true},
+			pieces:   enclosed(),
+			piece:    mustNewPiece(t, "id-player-1", 1, 2, 1, 1),
+			want:     true,
+			wantArea: 8,
+		},
+		{
+			name:     "opponents required: opening is exempt",
+			ruleset:  game.Rules{Opponents: game.ContactRequired},
+			pieces:   []game.Piece{},
+			piece:    mustNewPiece(t, "id-player-1", 1, 1, 1, 1),
+			want:     true,
+			wantArea: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "id-player-1", "id-player-2")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Ruleset = tt.ruleset
+			b.Pieces = pieces()
+			if tt.pieces != nil {
+				b.Pieces = tt.pieces
+			}
+
+			legal := false
+			for _, pl := range b.LegalPlacements(tt.piece.Player, tt.piece.Width, tt.piece.Height) {
+				legal = legal || (pl.Origin == tt.piece.Origin && !pl.Rotated)
+			}
+			if legal != tt.want {
+				t.Errorf("LegalPlacements() lists the piece = %t, want %t", legal, tt.want)
+			}
+
+			_, err = b.PlacePiece(tt.piece)
+			if (err == nil) != tt.want {
+				t.Fatalf("PlacePiece() error = %v, want placed %t", err, tt.want)
+			}
+			if tt.want && b.CoveredArea(tt.piece.Player) != tt.wantArea {
+				t.Errorf("CoveredArea() = %d, want %d", b.CoveredArea(tt.piece.Player), tt.wantArea)
+			}
+		})
+	}
+}
+
+// everywhere lets pieces go anywhere on the board.
+type everywhere struct{}
+
+func (everywhere) CanPlace(*game.Board, game.Piece, bool) bool {
+	return true
+}
+
+func TestBoard_PlacePiece_CustomRuleset(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "id-player-1", "id-player-2")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	b.Ruleset = everywhere{}
+
+	if _, err := b.PlacePiece(mustNewPiece(t, "id-player-1", 4, 4, 2, 2)); err != nil {
+		t.Errorf("PlacePiece() error = %v", err)
+	}
+	// Staying on the board is not up to the ruleset.
+	if _, err := b.PlacePiece(mustNewPiece(t, "id-player-1", 8, 12, 2, 2)); err == nil {
+		t.Errorf("PlacePiece() placed a piece off the board")
+	}
+}
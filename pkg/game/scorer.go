@@ -0,0 +1,68 @@
+package game
+
+// Scorer decides how many points a player has on a board.
+type Scorer interface {
+	Score(b *Board, playerID string) uint
+}
+
+// AreaScorer gives a point for every cell the player covers. This is the standard scoring.
+type AreaScorer struct{}
+
+// Score returns the area the player covers.
+func (AreaScorer) Score(b *Board, playerID string) uint {
+	return b.CoveredArea(playerID)
+}
+
+// RowBonusScorer gives a point for every covered cell, plus a bonus for every row of the board the player covers
+// from edge to edge on their own. Cells blocked by the map don't need covering, rows that are blocked entirely don't
+// count.
+type RowBonusScorer struct {
+	Bonus uint
+}
+
+// Score returns the area the player covers and the bonus for their complete rows.
+func (s RowBonusScorer) Score(b *Board, playerID string) uint {
+	g := b.occupancy()
+	score := g.area(playerID)
+
+	for y := 1; y <= g.height; y++ {
+		complete, open := true, false
+		for x := 1; x <= g.width && complete; x++ {
+			if !b.IsCoordinateWithin(Coordinate{X: uint8(x), Y: uint8(y)}) {
+				continue
+			}
+			open = true
+			complete = g.owner(x, y) == playerID
+		}
+		complete = complete && open
+		if complete {
+			score += s.Bonus
+		}
+	}
+
+	return score
+}
+
+// TerritoryScorer gives a point for every covered cell and for every free cell the player has enclosed, see
+// Board.Territories.
+type TerritoryScorer struct{}
+
+// Score returns the area the player covers and the area of their territory.
+func (TerritoryScorer) Score(b *Board, playerID string) uint {
+	return b.CoveredArea(playerID) + b.TerritoryArea(playerID)
+}
+
+func (b *Board) scorer() Scorer {
+	if b.Scorer == nil {
+		return AreaScorer{}
+	}
+	return b.Scorer
+}
+
+// updateScores recalculates the score of every player on the board.
+func (b *Board) updateScores() {
+	s := b.scorer()
+	for i := range b.Players {
+		b.Players[i].Score = s.Score(b, b.Players[i].ID)
+	}
+}
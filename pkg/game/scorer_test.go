@@ -0,0 +1,113 @@
+package game_test
+
+import (
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+func TestBoard_PlacePiece_Scores(t *testing.T) {
+	tests := []struct {
+		name   string
+		scorer game.Scorer
+		pieces []game.Piece
+		want   []uint
+	}{
+		{
+			name:   "no pieces, no score",
+			scorer: nil,
+			pieces: nil,
+			want:   []uint{0, 0},
+		},
+		{
+			name:   "default scoring counts covered cells",
+			scorer: nil,
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 3, 2),
+				mustNewPiece(t, "playerTwo", 10, 15, 3, 2),
+				mustNewPiece(t, "playerOne", 4, 1, 5, 1),
+				mustNewPiece(t, "playerTwo", 12, 9, 1, 6),
+			},
+			want: []uint{11, 12},
+		},
+		{
+			name:   "row bonus for rows covered edge to edge",
+			scorer: game.RowBonusScorer{Bonus: 10},
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 6, 2),
+				mustNewPiece(t, "playerTwo", 12, 16, 1, 1),
+				mustNewPiece(t, "playerOne", 7, 1, 6, 1),
+			},
+			want: []uint{28, 1},
+		},
+		{
+			name:   "row shared by players does not get a bonus",
+			scorer: game.RowBonusScorer{Bonus: 10},
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerTwo", 7, 11, 6, 6),
+				mustNewPiece(t, "playerOne", 1, 1, 1, 6),
+				mustNewPiece(t, "playerOne", 1, 7, 6, 6),
+			},
+			want: []uint{42, 36},
+		},
+		{
+			name:   "territory scoring counts enclosed cells",
+			scorer: game.TerritoryScorer{},
+			pieces: []game.Piece{
+				mustNewPiece(t, "playerOne", 1, 1, 3, 1),
+				mustNewPiece(t, "playerTwo", 12, 16, 1, 1),
+				mustNewPiece(t, "playerOne", 3, 2, 1, 2),
+				mustNewPiece(t, "playerOne", 1, 3, 2, 1),
+			},
+			want: []uint{9, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(12, 16, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Scorer = tt.scorer
+
+			for _, p := range tt.pieces {
+				if _, err := b.PlacePiece(p); err != nil {
+					t.Fatalf("PlacePiece() error = %v", err)
+				}
+			}
+
+			for i, want := range tt.want {
+				if got := b.Players[i].Score; got != want {
+					t.Errorf("PlacePiece() score of %s = %d, want %d", b.Players[i].ID, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGame_Result_Scorer(t *testing.T) {
+	g, err := game.NewWithConfig("1", game.Config{Scorer: game.RowBonusScorer{Bonus: 10}}, 12, 16, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewWithConfig() error = %v", err)
+	}
+	for _, p := range []game.Piece{
+		mustNewPiece(t, "playerOne", 1, 1, 6, 1),
+		mustNewPiece(t, "playerTwo", 7, 16, 6, 1),
+		mustNewPiece(t, "playerOne", 7, 1, 6, 1),
+		mustNewPiece(t, "playerTwo", 7, 10, 6, 6),
+	} {
+		if _, err := g.Board.PlacePiece(p); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+	g.Phase = game.GameOver
+	g.Ended = game.EndPassLimit
+
+	got, err := g.Result()
+	if err != nil {
+		t.Fatalf("Result() error = %v", err)
+	}
+	if got.Winner != "playerTwo" || got.Score["playerOne"] != 22 || got.Score["playerTwo"] != 42 {
+		t.Errorf("Result() got = %v", got)
+	}
+}
@@ -0,0 +1,81 @@
+package game
+
+// Snapshot is a read-only view of a board at the time it was taken. Nothing can change it, so any number of goroutines
+// can read it without locks while the board it was taken from plays on, eg to broadcast the state or for bots to
+// search from. Everything it hands out is a copy.
+type Snapshot struct {
+	board Board
+	// passLimit is how many passes in a row end the game.
+	passLimit int
+}
+
+// Snapshot takes a snapshot of the board as it is now. A round of passes ends the game, a board doesn't know about
+// the pass limit of the game it's played in, see Game.Snapshot.
+func (b *Board) Snapshot() Snapshot {
+	return Snapshot{board: b.Clone(), passLimit: len(b.Players)}
+}
+
+// Snapshot takes a snapshot of the board of the game as it is now, with the pass limit of the game.
+func (g *Game) Snapshot() Snapshot {
+	s := g.Board.Snapshot()
+	s.passLimit = g.Config.passLimit(g.playing())
+	return s
+}
+
+// Width returns the width of the board.
+func (s Snapshot) Width() uint8 {
+	return s.board.Width
+}
+
+// Height returns the height of the board.
+func (s Snapshot) Height() uint8 {
+	return s.board.Height
+}
+
+// Players returns the players with their scores, in the order they joined.
+func (s Snapshot) Players() []Player {
+	return append([]Player(nil), s.board.Players...)
+}
+
+// Pieces returns the pieces on the board, in the order they were placed.
+func (s Snapshot) Pieces() []Piece {
+	return clonePieces(s.board.Pieces)
+}
+
+// Roll returns the outstanding roll the next piece has to match, and false if nothing has been rolled.
+func (s Snapshot) Roll() (Roll, bool) {
+	if s.board.Roll == nil {
+		return Roll{}, false
+	}
+	return *s.board.Roll, true
+}
+
+// Dice returns the faces of the dice the game is played with.
+func (s Snapshot) Dice() Faces {
+	return append(Faces(nil), s.board.dice()...)
+}
+
+// PassLimit returns how many passes in a row end the game.
+func (s Snapshot) PassLimit() int {
+	return s.passLimit
+}
+
+// Board returns a board to play on from the snapshot, see Board.Clone.
+func (s Snapshot) Board() Board {
+	return s.board.Clone()
+}
+
+// LegalPlacements returns every spot where the player could put a piece of the given size, see Board.LegalPlacements.
+func (s Snapshot) LegalPlacements(playerID string, width uint8, height uint8) []Placement {
+	return s.board.LegalPlacements(playerID, width, height)
+}
+
+// Territories returns the regions the players have enclosed, see Board.Territories.
+func (s Snapshot) Territories() []Territory {
+	return s.board.Territories()
+}
+
+// FreeCells returns the number of cells that can still be placed on.
+func (s Snapshot) FreeCells() uint {
+	return s.board.FreeCells()
+}
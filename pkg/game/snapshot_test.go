@@ -0,0 +1,200 @@
+package game_test
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// snapshotBoard returns a board with a piece of each player on it and a roll outstanding.
+func snapshotBoard(t *testing.T) game.Board {
+	t.Helper()
+
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for _, p := range []game.Piece{mustNewPiece(t, "playerOne", 1, 1, 2, 2), mustNewPiece(t, "playerTwo", 7, 11, 2, 2)} {
+		if _, err := b.PlacePiece(p); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+	b.Roll = &game.Roll{Width: 1, Height: 2}
+	return b
+}
+
+func TestBoard_Snapshot(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(t *testing.T, b *game.Board)
+	}{
+		{
+			name: "placing a piece",
+			mutate: func(t *testing.T, b *game.Board) {
+				if _, err := b.PlacePiece(mustNewPiece(t, "playerOne", 3, 1, 1, 2)); err != nil {
+					t.Fatalf("PlacePiece() error = %v", err)
+				}
+			},
+		},
+		{
+			name: "changing players",
+			mutate: func(t *testing.T, b *game.Board) {
+				b.Players[0].Name = "changed"
+				b.Players[1].Score = 100
+			},
+		},
+		{
+			name: "changing the cells of a piece in place",
+			mutate: func(t *testing.T, b *game.Board) {
+				b.Pieces[0].Origin = game.Coordinate{X: 5, Y: 5}
+				b.Pieces[0].Coordinates[0] = game.Coordinate{X: 5, Y: 5}
+				b.Pieces[1].AdjacentFields[0] = game.Coordinate{X: 5, Y: 5}
+			},
+		},
+		{
+			name: "changing the roll in place",
+			mutate: func(t *testing.T, b *game.Board) {
+				b.Roll.Width = 6
+			},
+		},
+		{
+			name: "changing corners and dice",
+			mutate: func(t *testing.T, b *game.Board) {
+				b.Corners[0] = game.Coordinate{X: 5, Y: 5}
+				b.Dice = game.Faces{1, 2}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := snapshotBoard(t)
+			want := b.Clone()
+			s := b.Snapshot()
+
+			tt.mutate(t, &b)
+
+			if got := s.Board(); !reflect.DeepEqual(got, want) {
+				t.Errorf("Snapshot() changed with the board, got %v, want %v", got, want)
+			}
+			if got := s.Pieces(); !reflect.DeepEqual(got, want.Pieces) {
+				t.Errorf("Pieces() got = %v, want %v", got, want.Pieces)
+			}
+			if got := s.Players(); !reflect.DeepEqual(got, want.Players) {
+				t.Errorf("Players() got = %v, want %v", got, want.Players)
+			}
+			if got, ok := s.Roll(); !ok || got != *want.Roll {
+				t.Errorf("Roll() got = %v, %v, want %v", got, ok, *want.Roll)
+			}
+		})
+	}
+}
+
+func TestSnapshot_CopiesOut(t *testing.T) {
+	b := snapshotBoard(t)
+	want := b.Clone()
+	s := b.Snapshot()
+
+	// Changing what the snapshot hands out doesn't change the snapshot, or the board it was taken from.
+	pieces := s.Pieces()
+	pieces[0].Coordinates[0] = game.Coordinate{X: 5, Y: 5}
+	players := s.Players()
+	players[0].Score = 100
+	board := s.Board()
+	if _, err := board.PlacePiece(mustNewPiece(t, "playerOne", 3, 1, 1, 2)); err != nil {
+		t.Fatalf("PlacePiece() error = %v", err)
+	}
+
+	if got := s.Board(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Snapshot() changed, got %v, want %v", got, want)
+	}
+	if !reflect.DeepEqual(b, want) {
+		t.Errorf("changing the snapshot changed the board, got %v, want %v", b, want)
+	}
+}
+
+func TestSnapshot_LegalPlacements(t *testing.T) {
+	b := snapshotBoard(t)
+	s := b.Snapshot()
+	want := b.LegalPlacements("playerOne", 1, 2)
+
+	if _, err := b.PlacePiece(mustNewPiece(t, "playerOne", 3, 1, 1, 2)); err != nil {
+		t.Fatalf("PlacePiece() error = %v", err)
+	}
+
+	if got := s.LegalPlacements("playerOne", 1, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("LegalPlacements() got = %v, want %v", got, want)
+	}
+	if got, want := s.FreeCells(), uint(8*12-8); got != want {
+		t.Errorf("FreeCells() got = %d, want %d", got, want)
+	}
+}
+
+func TestGame_Snapshot(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        game.Config
+		wantDice      game.Faces
+		wantPassLimit int
+	}{
+		{
+			name:          "defaults",
+			wantDice:      game.D6,
+			wantPassLimit: 2,
+		},
+		{
+			name:          "dice and pass limit",
+			config:        game.Config{Dice: game.Faces{1, 1, 2, 3, 4, 6}, PassLimit: 5},
+			wantDice:      game.Faces{1, 1, 2, 3, 4, 6},
+			wantPassLimit: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, err := game.NewWithConfig("1", tt.config, 8, 12, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewWithConfig() error = %v", err)
+			}
+
+			s := g.Snapshot()
+			if got := s.Dice(); !reflect.DeepEqual(got, tt.wantDice) {
+				t.Errorf("Dice() got = %v, want %v", got, tt.wantDice)
+			}
+			if got := s.PassLimit(); got != tt.wantPassLimit {
+				t.Errorf("PassLimit() got = %d, want %d", got, tt.wantPassLimit)
+			}
+		})
+	}
+}
+
+// Run with -race to check that readers of a snapshot don't need to lock out the board it was taken from.
+func TestSnapshot_ConcurrentReaders(t *testing.T) {
+	b := snapshotBoard(t)
+	s := b.Snapshot()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				s.LegalPlacements("playerOne", 1, 2)
+				s.Territories()
+				s.Pieces()
+			}
+		}()
+	}
+
+	for _, p := range []game.Piece{mustNewPiece(t, "playerOne", 3, 1, 1, 2), mustNewPiece(t, "playerTwo", 6, 11, 1, 2)} {
+		b.Roll = nil
+		if _, err := b.PlacePiece(p); err != nil {
+			t.Errorf("PlacePiece() error = %v", err)
+		}
+	}
+	wg.Wait()
+
+	if got := len(s.Pieces()); got != 2 {
+		t.Errorf("Pieces() got %d pieces, want %d", got, 2)
+	}
+}
@@ -0,0 +1,155 @@
+package game
+
+import "sort"
+
+// Territory is a region of free cells walled off by the pieces of a single player, the edges of the board and cells
+// blocked by the map.
+type Territory struct {
+	Player string
+	// Cells are the free cells of the region, row by row from the top left.
+	Cells []Coordinate
+}
+
+// Territories returns the enclosed regions of the board, ordered by their first cell row by row from the top left. A
+// region of free cells connected along their edges belongs to a player if every piece bordering it is theirs. Regions
+// no piece borders, and regions that hold the starting corner of a player who hasn't placed anything yet, belong to
+// nobody and are not returned.
+func (b *Board) Territories() []Territory {
+	return b.enclosures(b.occupancy())
+}
+
+// TerritoryArea returns the number of free cells enclosed by the player.
+func (b *Board) TerritoryArea(playerID string) uint {
+	var area uint
+	for _, t := range b.Territories() {
+		if t.Player == playerID {
+			area += uint(len(t.Cells))
+		}
+	}
+	return area
+}
+
+// enclosures flood fills the free cells of the board grid region by region and returns the ones that are territory.
+func (b *Board) enclosures(g grid) []Territory {
+	var unopened []Coordinate
+	for i, p := range b.Players {
+		if i < len(b.Corners) && !b.hasPieces(p.ID) {
+			unopened = append(unopened, b.Corners[i])
+		}
+	}
+
+	seen := make([]bool, len(g.cells))
+	var territories []Territory
+
+	for y := 1; y <= g.height; y++ {
+		for x := 1; x <= g.width; x++ {
+			if seen[g.index(x, y)] || !b.isOpen(g, x, y) {
+				continue
+			}
+
+			cells, owner := b.region(g, seen, Coordinate{X: uint8(x), Y: uint8(y)})
+			if owner == "" || holdsAny(cells, unopened) {
+				continue
+			}
+
+			sort.Slice(cells, func(i, j int) bool {
+				return cells[i].Y < cells[j].Y || (cells[i].Y == cells[j].Y && cells[i].X < cells[j].X)
+			})
+			territories = append(territories, Territory{Player: owner, Cells: cells})
+		}
+	}
+
+	return territories
+}
+
+// region flood fills the free cells connected to the start along their edges, and marks them seen. It returns them
+// with the player whose pieces border them, or an empty string if no piece or pieces of more than one player do.
+func (b *Board) region(g grid, seen []bool, start Coordinate) ([]Coordinate, string) {
+	seen[g.index(int(start.X), int(start.Y))] = true
+	stack := []Coordinate{start}
+	var cells []Coordinate
+	owner, shared := "", false
+
+	for len(stack) > 0 {
+		c := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		cells = append(cells, c)
+
+		cx, cy := int(c.X), int(c.Y)
+		for _, n := range [4][2]int{{cx - 1, cy}, {cx + 1, cy}, {cx, cy - 1}, {cx, cy + 1}} {
+			nx, ny := n[0], n[1]
+			if !g.contains(nx, ny) {
+				continue
+			}
+			if b.isOpen(g, nx, ny) {
+				if !seen[g.index(nx, ny)] {
+					seen[g.index(nx, ny)] = true
+					stack = append(stack, Coordinate{X: uint8(nx), Y: uint8(ny)})
+				}
+				continue
+			}
+			// Blocked cells are walls like the edge of the board, only pieces have an owner.
+			switch o := g.owner(nx, ny); {
+			case o == "":
+			case owner == "":
+				owner = o
+			case o != owner:
+				shared = true
+			}
+		}
+	}
+
+	if shared {
+		return cells, ""
+	}
+	return cells, owner
+}
+
+// fences finds the territories of the board the first time a placement check needs them, so checking any number of
+// placements on the same board flood fills it once at most.
+type fences struct {
+	b *Board
+	// board is the grid of the whole board, if the check has one already. Built when needed otherwise.
+	board *grid
+	// owner holds the player who enclosed each cell of the board, row by row. Nil until it's needed.
+	owner []string
+}
+
+// trespassAt returns the first cell of the piece, row by row, that another player has enclosed.
+func (f *fences) trespassAt(p *Piece) (Coordinate, bool) {
+	if f.owner == nil {
+		if f.board == nil {
+			g := f.b.occupancy()
+			f.board = &g
+		}
+		g := *f.board
+		f.owner = make([]string, len(g.cells))
+		for _, t := range f.b.enclosures(g) {
+			for _, c := range t.Cells {
+				f.owner[g.index(int(c.X), int(c.Y))] = t.Player
+			}
+		}
+	}
+
+	width := int(f.b.Width)
+	for y := int(p.Origin.Y); y < int(p.Origin.Y)+int(p.Height); y++ {
+		for x := int(p.Origin.X); x < int(p.Origin.X)+int(p.Width); x++ {
+			if owner := f.owner[(y-1)*width+x-1]; owner != "" && owner != p.Player {
+				return Coordinate{X: uint8(x), Y: uint8(y)}, true
+			}
+		}
+	}
+	return Coordinate{}, false
+}
+
+// holdsAny checks whether any of the coordinates are among the cells.
+func holdsAny(cells []Coordinate, coordinates []Coordinate) bool {
+	for _, c := range coordinates {
+		for _, cell := range cells {
+			if cell == c {
+				return true
+			}
+		}
+	}
+	return false
+}
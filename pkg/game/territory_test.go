@@ -0,0 +1,126 @@
+package game_test
+
+import (
+	"reflect"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// enclosingPieces are the pieces of player one walling off A2:B2 in the top left corner of the board.
+func enclosingPieces(t *testing.T) []game.Piece {
+	t.Helper()
+
+	return []game.Piece{
+		mustNewPiece(t, "playerOne", 1, 1, 3, 1),
+		mustNewPiece(t, "playerOne", 3, 2, 1, 2),
+		mustNewPiece(t, "playerOne", 1, 3, 2, 1),
+	}
+}
+
+func TestBoard_Territories(t *testing.T) {
+	tests := []struct {
+		name   string
+		pieces func(t *testing.T) []game.Piece
+		want   []game.Territory
+	}{
+		{
+			name:   "empty board",
+			pieces: func(t *testing.T) []game.Piece { return nil },
+		},
+		{
+			name: "region walled off by one player",
+			pieces: func(t *testing.T) []game.Piece {
+				return append(enclosingPieces(t), mustNewPiece(t, "playerTwo", 8, 12, 1, 1))
+			},
+			want: []game.Territory{
+				{Player: "playerOne", Cells: []game.Coordinate{{X: 1, Y: 2}, {X: 2, Y: 2}}},
+			},
+		},
+		{
+			name:   "region holding the starting corner of a player yet to open",
+			pieces: enclosingPieces,
+			want: []game.Territory{
+				{Player: "playerOne", Cells: []game.Coordinate{{X: 1, Y: 2}, {X: 2, Y: 2}}},
+			},
+		},
+		{
+			name: "region bordered by both players",
+			pieces: func(t *testing.T) []game.Piece {
+				return []game.Piece{
+					mustNewPiece(t, "playerOne", 1, 1, 3, 1),
+					mustNewPiece(t, "playerOne", 3, 2, 1, 2),
+					mustNewPiece(t, "playerTwo", 1, 3, 2, 1),
+					mustNewPiece(t, "playerTwo", 8, 12, 1, 1),
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+			if err != nil {
+				t.Fatalf("NewBoard() error = %v", err)
+			}
+			b.Pieces = tt.pieces(t)
+
+			if got := b.Territories(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Territories() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBoard_Territories_Map(t *testing.T) {
+	m, err := game.ParseMap("walled", "1.#...\n..#...\n###...\n.....2\n")
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+	b, err := game.Bounds{MinWidth: 2, MaxWidth: 8, MinHeight: 2, MaxHeight: 8}.NewMapBoard(m, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewMapBoard() error = %v", err)
+	}
+	if _, err := b.PlacePiece(mustNewPiece(t, "playerOne", 1, 1, 1, 1)); err != nil {
+		t.Fatalf("PlacePiece() error = %v", err)
+	}
+
+	// Blocked cells wall off the corner like the edge of the board does.
+	want := []game.Territory{
+		{Player: "playerOne", Cells: []game.Coordinate{{X: 2, Y: 1}, {X: 1, Y: 2}, {X: 2, Y: 2}}},
+	}
+	if got := b.Territories(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Territories() got = %v, want %v", got, want)
+	}
+}
+
+func TestBoard_TerritoryArea(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	b.Pieces = append(enclosingPieces(t), mustNewPiece(t, "playerTwo", 8, 12, 1, 1))
+
+	tests := []struct {
+		name   string
+		player string
+		want   uint
+	}{
+		{
+			name:   "player with territory",
+			player: "playerOne",
+			want:   2,
+		},
+		{
+			name:   "player without territory",
+			player: "playerTwo",
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.TerritoryArea(tt.player); got != tt.want {
+				t.Errorf("TerritoryArea() got = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package render
+
+const (
+	glyphWidth  = 3
+	glyphHeight = 5
+)
+
+// font is a 3x5 bitmap font with just enough glyphs for piece dimensions. Each row is a bit mask, the highest of the
+// three bits being the leftmost pixel.
+var font = map[rune][glyphHeight]uint8{
+	'0': {0b111, 0b101, 0b101, 0b101, 0b111},
+	'1': {0b010, 0b110, 0b010, 0b010, 0b111},
+	'2': {0b111, 0b001, 0b111, 0b100, 0b111},
+	'3': {0b111, 0b001, 0b111, 0b001, 0b111},
+	'4': {0b101, 0b101, 0b111, 0b001, 0b001},
+	'5': {0b111, 0b100, 0b111, 0b001, 0b111},
+	'6': {0b111, 0b100, 0b111, 0b101, 0b111},
+	'7': {0b111, 0b001, 0b010, 0b010, 0b010},
+	'8': {0b111, 0b101, 0b111, 0b101, 0b111},
+	'9': {0b111, 0b101, 0b111, 0b001, 0b111},
+	'x': {0b000, 0b101, 0b010, 0b101, 0b000},
+}
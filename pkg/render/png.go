@@ -0,0 +1,93 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"strconv"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// PNG draws the board as a PNG image. It looks like the SVG without the row and column labels.
+func PNG(w io.Writer, b game.Board, opts Options) error {
+	return png.Encode(w, Image(b, opts))
+}
+
+// Image draws the board the way PNG does, for callers who want to encode it differently.
+func Image(b game.Board, opts Options) *image.RGBA {
+	size := opts.cellSize()
+	img := image.NewRGBA(image.Rect(0, 0, int(b.Width)*size+1, int(b.Height)*size+1))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	for x := 0; x <= int(b.Width); x++ {
+		fill(img, image.Rect(x*size, 0, x*size+1, img.Bounds().Dy()), gridLine)
+	}
+	for y := 0; y <= int(b.Height); y++ {
+		fill(img, image.Rect(0, y*size, img.Bounds().Dx(), y*size+1), gridLine)
+	}
+
+	for _, c := range blockedCells(b) {
+		fill(img, cellRect(c, 1, 1, size), blocked)
+	}
+
+	for _, p := range b.Pieces {
+		r := cellRect(p.Origin, p.Width, p.Height, size)
+		fill(img, r, outline)
+		fill(img, r.Inset(2), opts.color(b, p.Player))
+		label(img, r, strconv.Itoa(int(p.Width))+"x"+strconv.Itoa(int(p.Height)), background)
+	}
+
+	if pl := opts.Highlight; pl != nil {
+		r := cellRect(pl.Origin, pl.Width, pl.Height, size)
+		draw.Draw(img, r, image.NewUniform(highlight), image.Point{}, draw.Over)
+	}
+
+	return img
+}
+
+func cellRect(origin game.Coordinate, width uint8, height uint8, size int) image.Rectangle {
+	x, y := (int(origin.X)-1)*size, (int(origin.Y)-1)*size
+	return image.Rect(x, y, x+int(width)*size+1, y+int(height)*size+1)
+}
+
+func fill(img *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// label writes the text in the middle of the rectangle with the bitmap font, as large as fits. Text that doesn't fit
+// at all is left out.
+func label(img *image.RGBA, r image.Rectangle, text string, c color.Color) {
+	width := len(text)*(glyphWidth+1) - 1
+	scale := minInt((r.Dx()-4)/width, (r.Dy()-4)/glyphHeight)
+	if scale < 1 {
+		return
+	}
+	scale = minInt(scale, 3)
+
+	x := r.Min.X + (r.Dx()-width*scale)/2
+	y := r.Min.Y + (r.Dy()-glyphHeight*scale)/2
+	for _, ch := range text {
+		rows, ok := font[ch]
+		if !ok {
+			continue
+		}
+		for row, bits := range rows {
+			for col := 0; col < glyphWidth; col++ {
+				if bits&(1<<(glyphWidth-1-col)) != 0 {
+					fill(img, image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale), c)
+				}
+			}
+		}
+		x += (glyphWidth + 1) * scale
+	}
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,92 @@
+package render_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+	"javorszky/dice-territory-game/v2/pkg/render"
+)
+
+func TestPNG(t *testing.T) {
+	palette := render.Palette{{R: 0xff, A: 0xff}, {B: 0xff, A: 0xff}}
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+
+	tests := []struct {
+		name   string
+		opts   render.Options
+		bounds image.Rectangle
+		pixels map[image.Point]color.RGBA
+	}{
+		{
+			name:   "pieces are filled with the colours of their players",
+			opts:   render.Options{CellSize: 10, Palette: palette},
+			bounds: image.Rect(0, 0, 81, 121),
+			pixels: map[image.Point]color.RGBA{
+				// Inside the 2x3 piece of playerOne, clear of its label.
+				{X: 4, Y: 4}: palette[0],
+				// Inside the 4x1 piece of playerTwo.
+				{X: 44, Y: 114}: palette[1],
+				// Free cell.
+				{X: 55, Y: 55}: white,
+			},
+		},
+		{
+			name: "highlighted placement is shaded",
+			opts: render.Options{
+				CellSize:  10,
+				Palette:   palette,
+				Highlight: &game.Placement{Origin: game.Coordinate{X: 6, Y: 6}, Width: 1, Height: 1},
+			},
+			bounds: image.Rect(0, 0, 81, 121),
+			pixels: map[image.Point]color.RGBA{
+				{X: 55, Y: 55}: {R: 0xe1, G: 0xe1, B: 0xe1, A: 0xff},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := render.PNG(&buf, testBoard(t), tt.opts); err != nil {
+				t.Fatalf("PNG() error = %v", err)
+			}
+
+			img, err := png.Decode(&buf)
+			if err != nil {
+				t.Fatalf("png.Decode() error = %v", err)
+			}
+			if img.Bounds() != tt.bounds {
+				t.Errorf("PNG() bounds = %v, want %v", img.Bounds(), tt.bounds)
+			}
+			for p, want := range tt.pixels {
+				if got := color.RGBAModel.Convert(img.At(p.X, p.Y)); got != want {
+					t.Errorf("PNG() pixel at %v = %v, want %v", p, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImage_LabelsPieces(t *testing.T) {
+	opts := render.Options{CellSize: 20, Palette: render.Palette{{R: 0xff, A: 0xff}, {B: 0xff, A: 0xff}}}
+	img := render.Image(testBoard(t), opts)
+
+	// The label of the 2x3 piece is drawn in white somewhere inside it.
+	white := color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	found := false
+	for y := 2; y < 58 && !found; y++ {
+		for x := 2; x < 38; x++ {
+			if img.RGBAAt(x, y) == white {
+				found = true
+				break
+			}
+		}
+	}
+	if !found {
+		t.Error("Image() did not label the 2x3 piece")
+	}
+}
@@ -0,0 +1,79 @@
+// render package draws boards as images for the web client, shared links and game over summaries.
+package render
+
+import (
+	"image/color"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// Palette holds the colours of the players, in the order they joined the board.
+type Palette []color.RGBA
+
+// DefaultPalette is red, blue, green and yellow.
+var DefaultPalette = Palette{
+	{R: 0xe0, G: 0x4f, B: 0x4f, A: 0xff},
+	{R: 0x4f, G: 0x7c, B: 0xe0, A: 0xff},
+	{R: 0x4f, G: 0xb0, B: 0x5f, A: 0xff},
+	{R: 0xe0, G: 0xb8, B: 0x3f, A: 0xff},
+}
+
+var (
+	background = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	gridLine   = color.RGBA{R: 0xdd, G: 0xdd, B: 0xdd, A: 0xff}
+	outline    = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	unknown    = color.RGBA{R: 0x99, G: 0x99, B: 0x99, A: 0xff}
+	blocked    = color.RGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xff}
+	highlight  = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0x40}
+)
+
+const defaultCellSize = 24
+
+// Options controls how a board is drawn.
+type Options struct {
+	// CellSize is the width and height of a cell in pixels. Zero means 24.
+	CellSize int
+	// Palette colours the players' pieces. Nil means DefaultPalette.
+	Palette Palette
+	// Highlight marks a placement on the board, eg the last move or the one being considered.
+	Highlight *game.Placement
+}
+
+// blockedCells returns the cells of the board its map blocks.
+func blockedCells(b game.Board) []game.Coordinate {
+	if b.Map == nil {
+		return nil
+	}
+
+	var cells []game.Coordinate
+	for y := 1; y <= int(b.Height); y++ {
+		for x := 1; x <= int(b.Width); x++ {
+			if c := (game.Coordinate{X: uint8(x), Y: uint8(y)}); b.Map.Blocked(c) {
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+func (o Options) cellSize() int {
+	if o.CellSize > 0 {
+		return o.CellSize
+	}
+	return defaultCellSize
+}
+
+// color returns the colour of the player, grey for pieces of players who are not on the board.
+func (o Options) color(b game.Board, player string) color.RGBA {
+	palette := o.Palette
+	if len(palette) == 0 {
+		palette = DefaultPalette
+	}
+
+	for i, p := range b.Players {
+		if p.ID == player {
+			return palette[i%len(palette)]
+		}
+	}
+	return unknown
+}
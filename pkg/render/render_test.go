@@ -0,0 +1,34 @@
+package render_test
+
+import (
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// testBoard is a small board with a piece for each of the two players.
+func testBoard(t *testing.T) game.Board {
+	t.Helper()
+
+	b, err := game.NewBoard(8, 12, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	for _, p := range []struct {
+		player        string
+		x, y          uint8
+		width, height uint8
+	}{
+		{"playerOne", 1, 1, 2, 3},
+		{"playerTwo", 5, 12, 4, 1},
+	} {
+		piece, err := game.NewPiece(p.player, p.x, p.y, p.width, p.height)
+		if err != nil {
+			t.Fatalf("NewPiece() error = %v", err)
+		}
+		if _, err := b.PlacePiece(piece); err != nil {
+			t.Fatalf("PlacePiece() error = %v", err)
+		}
+	}
+	return b
+}
@@ -0,0 +1,95 @@
+package render
+
+import (
+	"bufio"
+	"fmt"
+	"html"
+	"image/color"
+	"io"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+)
+
+// labelMargin is the room left of and above the board for the row and column labels, in cells.
+const labelMargin = 1
+
+// SVG draws the board as an SVG image with labelled rows and columns. Every piece is filled with the colour of its
+// player and labelled with its dimensions.
+func SVG(w io.Writer, b game.Board, opts Options) error {
+	bw := bufio.NewWriter(w)
+	size := opts.cellSize()
+	width := (int(b.Width) + labelMargin) * size
+	height := (int(b.Height) + labelMargin) * size
+	font := size / 2
+
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="%d">`+"\n",
+		width, height, width, height, font)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, hex(background))
+
+	// Labels
+	for x := 1; x <= int(b.Width); x++ {
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="central">%s</text>`+"\n",
+			(x+labelMargin)*size-size/2, size/2, game.ColumnName(x))
+	}
+	for y := 1; y <= int(b.Height); y++ {
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="central">%d</text>`+"\n",
+			size/2, (y+labelMargin)*size-size/2, y)
+	}
+
+	// Grid
+	fmt.Fprintf(bw, `<g stroke="%s" stroke-width="1">`+"\n", hex(gridLine))
+	for x := 0; x <= int(b.Width); x++ {
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", (x+labelMargin)*size, labelMargin*size, (x+labelMargin)*size, height)
+	}
+	for y := 0; y <= int(b.Height); y++ {
+		fmt.Fprintf(bw, `<line x1="%d" y1="%d" x2="%d" y2="%d"/>`+"\n", labelMargin*size, (y+labelMargin)*size, width, (y+labelMargin)*size)
+	}
+	fmt.Fprint(bw, "</g>\n")
+
+	// Cells blocked by the map
+	for _, c := range blockedCells(b) {
+		x, y := cellOrigin(c, size)
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n", x, y, size, size, hex(blocked))
+	}
+
+	// Starting corners
+	for i, c := range b.Corners {
+		if i >= len(b.Players) {
+			break
+		}
+		x, y := cellOrigin(c, size)
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="none" stroke="%s" stroke-width="2" stroke-dasharray="4 2"/>`+"\n",
+			x+2, y+2, size-4, size-4, hex(opts.color(b, b.Players[i].ID)))
+	}
+
+	// Pieces
+	for _, p := range b.Pieces {
+		x, y := cellOrigin(p.Origin, size)
+		w, h := int(p.Width)*size, int(p.Height)*size
+		fmt.Fprintf(bw, `<g><title>%s</title>`, html.EscapeString(p.Player))
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" stroke="%s" stroke-width="2"/>`,
+			x, y, w, h, hex(opts.color(b, p.Player)), hex(outline))
+		fmt.Fprintf(bw, `<text x="%d" y="%d" text-anchor="middle" dominant-baseline="central" fill="%s">%dx%d</text>`,
+			x+w/2, y+h/2, hex(background), p.Width, p.Height)
+		fmt.Fprint(bw, "</g>\n")
+	}
+
+	// Highlight
+	if pl := opts.Highlight; pl != nil {
+		x, y := cellOrigin(pl.Origin, size)
+		fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s" fill-opacity="%.2f" stroke="%s" stroke-width="3" stroke-dasharray="6 3"/>`+"\n",
+			x, y, int(pl.Width)*size, int(pl.Height)*size, hex(highlight), float64(highlight.A)/0xff, hex(outline))
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+	return bw.Flush()
+}
+
+// cellOrigin returns the top left corner of a cell in pixels.
+func cellOrigin(c game.Coordinate, size int) (int, int) {
+	return (int(c.X) - 1 + labelMargin) * size, (int(c.Y) - 1 + labelMargin) * size
+}
+
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
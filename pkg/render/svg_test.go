@@ -0,0 +1,148 @@
+package render_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"math"
+	"strings"
+	"testing"
+
+	"javorszky/dice-territory-game/v2/pkg/game"
+	"javorszky/dice-territory-game/v2/pkg/render"
+)
+
+func TestSVG(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    render.Options
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "pieces are coloured and labelled",
+			opts: render.Options{},
+			want: []string{
+				`width="216" height="312"`,
+				`<rect x="24" y="24" width="48" height="72" fill="#e04f4f"`,
+				`>2x3</text>`,
+				`<rect x="120" y="288" width="96" height="24" fill="#4f7ce0"`,
+				`>4x1</text>`,
+				`>A</text>`,
+				`>H</text>`,
+				`>12</text>`,
+			},
+			notWant: []string{`stroke-dasharray="6 3"`},
+		},
+		{
+			name: "custom palette and cell size",
+			opts: render.Options{
+				CellSize: 10,
+				Palette:  render.Palette{{R: 1, G: 2, B: 3, A: 0xff}, {R: 4, G: 5, B: 6, A: 0xff}},
+			},
+			want: []string{
+				`width="90" height="130"`,
+				`<rect x="10" y="10" width="20" height="30" fill="#010203"`,
+				`<rect x="50" y="120" width="40" height="10" fill="#040506"`,
+			},
+		},
+		{
+			name: "highlighted placement",
+			opts: render.Options{Highlight: &game.Placement{Origin: game.Coordinate{X: 3, Y: 1}, Width: 1, Height: 2}},
+			want: []string{`<rect x="72" y="24" width="24" height="48"`, `stroke-dasharray="6 3"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := render.SVG(&buf, testBoard(t), tt.opts); err != nil {
+				t.Fatalf("SVG() error = %v", err)
+			}
+			got := buf.String()
+
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("SVG() is missing %q, got\n%s", w, got)
+				}
+			}
+			for _, w := range tt.notWant {
+				if strings.Contains(got, w) {
+					t.Errorf("SVG() should not contain %q, got\n%s", w, got)
+				}
+			}
+
+			if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+				t.Errorf("SVG() is not well-formed XML: %v", err)
+			}
+		})
+	}
+}
+
+func TestSVG_Map(t *testing.T) {
+	m, err := game.ParseMap("test", "1.......\n"+strings.Repeat("........\n", 4)+"...##...\n"+strings.Repeat("........\n", 5)+".......2\n")
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+	b, err := game.NewMapBoard(m, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewMapBoard() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render.SVG(&buf, b, render.Options{}); err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+	for _, want := range []string{`<rect x="96" y="144" width="24" height="24" fill="#555555"/>`, `<rect x="120" y="144" width="24" height="24" fill="#555555"/>`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("SVG() is missing %q", want)
+		}
+	}
+	if got := strings.Count(buf.String(), `fill="#555555"`); got != 2 {
+		t.Errorf("SVG() has %d blocked cells, want 2", got)
+	}
+}
+
+func TestSVG_LargestMap(t *testing.T) {
+	row := strings.Repeat(".", math.MaxUint8)
+	m, err := game.ParseMap("largest", "1"+row[1:]+"\n"+row[1:]+"#\n"+row[1:]+"2\n")
+	if err != nil {
+		t.Fatalf("ParseMap() error = %v", err)
+	}
+	b, err := game.Bounds{MinWidth: 2, MaxWidth: math.MaxUint8, MinHeight: 2, MaxHeight: math.MaxUint8}.NewMapBoard(m, "playerOne", "playerTwo")
+	if err != nil {
+		t.Fatalf("NewMapBoard() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render.SVG(&buf, b, render.Options{}); err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+	if got := strings.Count(buf.String(), `fill="#555555"`); got != 1 {
+		t.Errorf("SVG() has %d blocked cells, want 1", got)
+	}
+}
+
+func TestSVG_EscapesPlayers(t *testing.T) {
+	b, err := game.NewBoard(8, 12, "<one>", "two&")
+	if err != nil {
+		t.Fatalf("NewBoard() error = %v", err)
+	}
+	p, err := game.NewPiece("<one>", 1, 1, 1, 1)
+	if err != nil {
+		t.Fatalf("NewPiece() error = %v", err)
+	}
+	if _, err := b.PlacePiece(p); err != nil {
+		t.Fatalf("PlacePiece() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render.SVG(&buf, b, render.Options{}); err != nil {
+		t.Fatalf("SVG() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "<title>&lt;one&gt;</title>") {
+		t.Errorf("SVG() did not escape the player, got\n%s", buf.String())
+	}
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Errorf("SVG() is not well-formed XML: %v", err)
+	}
+}